@@ -12,30 +12,49 @@ import (
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/service/ssm"
 	"github.com/byteness/aws-vault/v7/config"
+	"gopkg.in/yaml.v3"
 )
 
 // ConfigValidateCommandInput contains the input for config validate.
 type ConfigValidateCommandInput struct {
-	Paths      []string // Local file paths to validate
-	SSMPaths   []string // SSM paths to load and validate
-	ConfigType string   // Override detected type (policy, approval, breakglass, ratelimit, bootstrap)
-	Output     string   // human, json
-	Region     string   // AWS region for SSM
-	AWSProfile string   // Optional AWS profile for SSM credentials
+	Paths           []string // Local file paths, or oci://, git://, s3:// source refs, to validate
+	SSMPaths        []string // SSM paths to load and validate
+	ConfigType      string   // Override detected type (policy, approval, breakglass, ratelimit, bootstrap)
+	Output          string   // human, json
+	Region          string   // AWS region for SSM
+	AWSProfile      string   // Optional AWS profile for SSM credentials
+	VerifySignature bool     // Require a valid cosign signature for non-local sources
+	Signature       string   // Path to the detached signature (required with VerifySignature)
+	PubKey          string   // Path to a cosign public key (omit for keyless/Fulcio verification)
+	FulcioIdentity  string   // Expected signer identity for keyless verification (used when PubKey is empty)
 
 	// For testing
 	Stdout   *os.File
 	Stderr   *os.File
 	SSMFetch func(ctx context.Context, path string) ([]byte, error) // Override for testing
+	Fetcher  config.Fetcher                                         // Override for testing oci/git/s3 sources
+	Verifier config.SignatureVerifier                               // Override for testing signature verification
+}
+
+// ConfigMergeCommandInput contains the input for config merge.
+type ConfigMergeCommandInput struct {
+	Path   string // Primary local file to merge overlays into
+	Output string // yaml, json
+
+	// For testing
+	Stdout *os.File
+	Stderr *os.File
 }
 
 // ConfigGenerateCommandInput contains the input for config generate.
 type ConfigGenerateCommandInput struct {
-	Template   string   // basic, approvals, full
-	Profiles   []string // AWS profiles to include
-	Users      []string // Users for approvers/break-glass
-	OutputDir  string   // Directory to write files (empty = stdout)
-	JSONOutput bool     // Output as JSON instead of YAML files
+	Template    string   // basic, approvals, full
+	Profiles    []string // AWS profiles to include
+	Users       []string // Users for approvers/break-glass
+	OutputDir   string   // Directory to write files (empty = stdout)
+	JSONOutput  bool     // Output as JSON instead of YAML files
+	Spec        string   // Path to a spec file describing profiles/users/groups/approval chains/rate-limit tiers
+	TemplateDir string   // Directory of override templates (used with Spec; falls back to built-ins if empty)
 
 	// For testing
 	Stdout *os.File
@@ -53,16 +72,28 @@ func ConfigureConfigCommand(app *kingpin.Application, s *Sentinel) {
 
 	cmd := configCmd.Command("validate", "Validate configuration files")
 
-	cmd.Arg("paths", "Local files to validate").
+	cmd.Arg("paths", "Local files, or oci://, git://, s3:// source refs, to validate").
 		StringsVar(&input.Paths)
 
-	cmd.Flag("path", "Local file to validate (repeatable)").
+	cmd.Flag("path", "Local file or oci://, git://, s3:// source ref to validate (repeatable)").
 		Short('p').
 		StringsVar(&input.Paths)
 
 	cmd.Flag("ssm", "SSM parameter to load and validate (repeatable)").
 		StringsVar(&input.SSMPaths)
 
+	cmd.Flag("verify-signature", "Require a valid cosign signature for oci://, git://, and s3:// sources").
+		BoolVar(&input.VerifySignature)
+
+	cmd.Flag("signature", "Path to the detached cosign signature (required with --verify-signature)").
+		StringVar(&input.Signature)
+
+	cmd.Flag("pubkey", "Path to a cosign public key (omit to verify against a Fulcio identity instead)").
+		StringVar(&input.PubKey)
+
+	cmd.Flag("fulcio-identity", "Expected signer identity for keyless verification (used when --pubkey is omitted)").
+		StringVar(&input.FulcioIdentity)
+
 	cmd.Flag("type", "Config type: policy, approval, breakglass, ratelimit, bootstrap (auto-detect if not specified)").
 		EnumVar(&input.ConfigType, "policy", "approval", "breakglass", "ratelimit", "bootstrap", "")
 
@@ -89,6 +120,41 @@ func ConfigureConfigCommand(app *kingpin.Application, s *Sentinel) {
 
 	// Configure generate subcommand
 	configureConfigGenerateCommand(configCmd, app)
+
+	// Configure merge subcommand
+	configureConfigMergeCommand(configCmd, app)
+
+	// Configure lint subcommand
+	configureConfigLintCommand(configCmd, app)
+
+	// Configure diff subcommand
+	configureConfigDiffCommand(configCmd, app)
+}
+
+// configureConfigMergeCommand sets up the merge subcommand.
+func configureConfigMergeCommand(parent *kingpin.CmdClause, app *kingpin.Application) {
+	mergeInput := ConfigMergeCommandInput{}
+
+	cmd := parent.Command("merge", "Print the effective config after merging conf.d overlays")
+
+	cmd.Arg("path", "Primary local config file").
+		Required().
+		StringVar(&mergeInput.Path)
+
+	cmd.Flag("output", "Output format: yaml (default), json").
+		Default("yaml").
+		EnumVar(&mergeInput.Output, "yaml", "json")
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		exitCode, err := ConfigMergeCommand(mergeInput)
+		if err != nil {
+			app.FatalIfError(err, "config merge")
+		}
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return nil
+	})
 }
 
 // configureConfigGenerateCommand sets up the generate subcommand.
@@ -99,12 +165,10 @@ func configureConfigGenerateCommand(parent *kingpin.CmdClause, app *kingpin.Appl
 
 	cmd.Flag("template", "Template type: basic, approvals, full").
 		Short('t').
-		Required().
 		EnumVar(&genInput.Template, "basic", "approvals", "full")
 
 	cmd.Flag("profile", "AWS profile to include (repeatable)").
 		Short('p').
-		Required().
 		StringsVar(&genInput.Profiles)
 
 	cmd.Flag("user", "User for approvers/break-glass (repeatable, required for approvals/full)").
@@ -118,6 +182,12 @@ func configureConfigGenerateCommand(parent *kingpin.CmdClause, app *kingpin.Appl
 	cmd.Flag("json", "Output as JSON instead of YAML").
 		BoolVar(&genInput.JSONOutput)
 
+	cmd.Flag("spec", "Spec file describing profiles, users, groups, approval chains, and rate-limit tiers (renders via templates instead of --template/--profile/--user)").
+		StringVar(&genInput.Spec)
+
+	cmd.Flag("template-dir", "Directory of override templates for --spec (defaults to the built-in basic/approvals/full presets)").
+		StringVar(&genInput.TemplateDir)
+
 	cmd.Action(func(c *kingpin.ParseContext) error {
 		exitCode, err := ConfigGenerateCommand(genInput)
 		if err != nil {
@@ -161,18 +231,42 @@ func ConfigValidateCommand(ctx context.Context, input ConfigValidateCommandInput
 		}
 	}
 
+	if input.VerifySignature && input.Signature == "" {
+		err := fmt.Errorf("--verify-signature requires --signature")
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1, err
+	}
+
+	fetcher := input.Fetcher
+	if fetcher == nil {
+		fetcher = config.NewDefaultFetcher()
+	}
+	verifier := input.Verifier
+	if verifier == nil {
+		verifier = config.NewCosignVerifier()
+	}
+
 	// Collect all results
 	var results []config.ValidationResult
 
-	// Validate local files
+	// Validate each path: local files are read directly, oci://, git://, and
+	// s3:// refs are resolved through fetcher.
 	for _, path := range input.Paths {
 		// Skip empty paths (from combining args and flags)
 		if path == "" {
 			continue
 		}
 
-		// Read file content
-		content, err := os.ReadFile(path)
+		scheme, _ := config.ParseSourceRef(path)
+
+		// Fetch content
+		var content []byte
+		var err error
+		if scheme == config.SchemeLocal {
+			content, err = os.ReadFile(path)
+		} else {
+			content, err = fetcher.Fetch(ctx, path)
+		}
 		if err != nil {
 			results = append(results, config.ValidationResult{
 				ConfigType: configType,
@@ -180,13 +274,35 @@ func ConfigValidateCommand(ctx context.Context, input ConfigValidateCommandInput
 				Valid:      false,
 				Issues: []config.ValidationIssue{{
 					Severity:   config.SeverityError,
-					Message:    fmt.Sprintf("failed to read file: %v", err),
-					Suggestion: "verify the file path exists and is readable",
+					Message:    fmt.Sprintf("failed to read %s: %v", path, err),
+					Suggestion: "verify the source exists and is reachable",
 				}},
 			})
 			continue
 		}
 
+		// Verify signature for remote sources, if requested
+		if input.VerifySignature && scheme != config.SchemeLocal {
+			verifyOpts := config.VerifyOptions{
+				SignaturePath:  input.Signature,
+				PubKeyPath:     input.PubKey,
+				FulcioIdentity: input.FulcioIdentity,
+			}
+			if err := verifier.Verify(ctx, content, verifyOpts); err != nil {
+				results = append(results, config.ValidationResult{
+					ConfigType: configType,
+					Source:     path,
+					Valid:      false,
+					Issues: []config.ValidationIssue{{
+						Severity:   config.SeverityError,
+						Message:    fmt.Sprintf("signature verification failed: %v", err),
+						Suggestion: "confirm --pubkey or the Fulcio identity matches the signer, and --signature points at the detached signature",
+					}},
+				})
+				continue
+			}
+		}
+
 		// Detect or use specified type
 		ct := configType
 		if ct == "" {
@@ -196,6 +312,16 @@ func ConfigValidateCommand(ctx context.Context, input ConfigValidateCommandInput
 		// Validate
 		result := config.Validate(ct, content, path)
 		results = append(results, result)
+
+		// If this is a local config with conf.d overlays or an include list,
+		// also validate the merged effective config - a fragment can be
+		// individually valid yet produce a conflicting or invalid merged
+		// result. Remote sources are resolved as a single document.
+		if scheme == config.SchemeLocal {
+			if mergedResult, ok := validateMergedConfig(path, ct); ok {
+				results = append(results, mergedResult)
+			}
+		}
 	}
 
 	// Validate SSM parameters
@@ -286,6 +412,60 @@ func ConfigValidateCommand(ctx context.Context, input ConfigValidateCommandInput
 	return 0, nil
 }
 
+// validateMergedConfig resolves and merges any conf.d overlay or include list
+// for path and validates the effective result. ok is false when path has no
+// overlay fragments, since plain single-file configs don't need a second pass.
+func validateMergedConfig(path string, configType config.ConfigType) (result config.ValidationResult, ok bool) {
+	fragments, err := config.ResolveIncludes(path)
+	if err != nil {
+		return config.ValidationResult{
+			ConfigType: configType,
+			Source:     path + " (merged)",
+			Valid:      false,
+			Issues: []config.ValidationIssue{{
+				Severity:   config.SeverityError,
+				Message:    fmt.Sprintf("failed to resolve includes: %v", err),
+				Suggestion: "verify the include list or conf.d directory paths exist and are readable",
+			}},
+		}, true
+	}
+	if len(fragments) == 0 {
+		return config.ValidationResult{}, false
+	}
+
+	merged, conflicts, err := config.MergeFragments(path, fragments)
+	if err != nil {
+		return config.ValidationResult{
+			ConfigType: configType,
+			Source:     path + " (merged)",
+			Valid:      false,
+			Issues: []config.ValidationIssue{{
+				Severity:   config.SeverityError,
+				Message:    fmt.Sprintf("failed to merge overlays: %v", err),
+				Suggestion: "check YAML syntax in each overlay fragment",
+			}},
+		}, true
+	}
+
+	ct := configType
+	if ct == "" {
+		ct = config.DetectConfigType(merged)
+	}
+
+	result = config.Validate(ct, merged, path+" (merged)")
+	for _, c := range conflicts {
+		result.Valid = false
+		result.Issues = append(result.Issues, config.ValidationIssue{
+			Severity:   config.SeverityError,
+			Location:   c.Path,
+			Message:    fmt.Sprintf("conflicting value for %q from %s (line %d)", c.Path, c.Source, c.Line),
+			Suggestion: "use !override on a list, or remove the duplicate key from one fragment",
+		})
+	}
+
+	return result, true
+}
+
 // outputHuman outputs validation results in human-readable format.
 func outputHuman(w *os.File, all config.AllResults) {
 	total := len(all.Results)
@@ -401,10 +581,11 @@ func pluralize(count int) string {
 	return "s"
 }
 
-// ConfigGenerateCommand executes the config generate command logic.
-// It returns exit code (0=success, 1=error) and any fatal error.
-func ConfigGenerateCommand(input ConfigGenerateCommandInput) (int, error) {
-	// Set up I/O
+// ConfigMergeCommand executes the config merge command logic, printing the
+// effective configuration after composing input.Path with any conf.d overlay
+// fragments or explicit include list. It returns exit code (0=success,
+// 1=error) and any fatal error.
+func ConfigMergeCommand(input ConfigMergeCommandInput) (int, error) {
 	stdout := input.Stdout
 	if stdout == nil {
 		stdout = os.Stdout
@@ -414,21 +595,113 @@ func ConfigGenerateCommand(input ConfigGenerateCommandInput) (int, error) {
 		stderr = os.Stderr
 	}
 
-	// Validate template ID
-	templateID := config.TemplateID(input.Template)
-	if !templateID.IsValid() {
-		err := fmt.Errorf("invalid template: %s (valid: basic, approvals, full)", input.Template)
-		fmt.Fprintf(stderr, "Error: %v\n", err)
+	fragments, err := config.ResolveIncludes(input.Path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to resolve includes: %v\n", err)
 		return 1, err
 	}
 
-	// Generate the template
-	output, err := config.GenerateTemplate(templateID, input.Profiles, input.Users)
+	merged, conflicts, err := config.MergeFragments(input.Path, fragments)
 	if err != nil {
-		fmt.Fprintf(stderr, "Error: %v\n", err)
+		fmt.Fprintf(stderr, "Error: failed to merge overlays: %v\n", err)
 		return 1, err
 	}
 
+	for _, c := range conflicts {
+		fmt.Fprintf(stderr, "Warning: conflicting value for %q from %s (line %d)\n", c.Path, c.Source, c.Line)
+	}
+
+	if strings.ToLower(input.Output) == "json" {
+		var doc interface{}
+		if err := yaml.Unmarshal(merged, &doc); err != nil {
+			fmt.Fprintf(stderr, "Error: failed to parse merged config: %v\n", err)
+			return 1, err
+		}
+		data, err := json.MarshalIndent(normalizeForJSON(doc), "", "  ")
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: failed to marshal JSON: %v\n", err)
+			return 1, err
+		}
+		fmt.Fprintln(stdout, string(data))
+	} else {
+		fmt.Fprint(stdout, string(merged))
+	}
+
+	if len(conflicts) > 0 {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// normalizeForJSON converts the map[interface{}]interface{} values that
+// yaml.v3 may produce for nested maps into map[string]interface{} so
+// json.Marshal doesn't reject them.
+func normalizeForJSON(v interface{}) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		out := make(map[string]interface{}, len(val))
+		for k, v := range val {
+			out[k] = normalizeForJSON(v)
+		}
+		return out
+	case []interface{}:
+		out := make([]interface{}, len(val))
+		for i, v := range val {
+			out[i] = normalizeForJSON(v)
+		}
+		return out
+	default:
+		return val
+	}
+}
+
+// ConfigGenerateCommand executes the config generate command logic.
+// It returns exit code (0=success, 1=error) and any fatal error.
+func ConfigGenerateCommand(input ConfigGenerateCommandInput) (int, error) {
+	// Set up I/O
+	stdout := input.Stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	stderr := input.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	var output *config.TemplateOutput
+	if input.Spec != "" {
+		spec, err := config.LoadSpec(input.Spec)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1, err
+		}
+		output, err = config.GenerateFromSpec(spec, input.TemplateDir)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1, err
+		}
+	} else {
+		if input.Template == "" || len(input.Profiles) == 0 {
+			err := fmt.Errorf("either --spec, or both --template and --profile, are required")
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1, err
+		}
+
+		templateID := config.TemplateID(input.Template)
+		if !templateID.IsValid() {
+			err := fmt.Errorf("invalid template: %s (valid: basic, approvals, full)", input.Template)
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1, err
+		}
+
+		var err error
+		output, err = config.GenerateTemplate(templateID, input.Profiles, input.Users)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1, err
+		}
+	}
+
 	// Handle output mode
 	if input.JSONOutput {
 		return outputGenerateJSON(stdout, output)
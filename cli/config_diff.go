@@ -0,0 +1,156 @@
+package cli
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/byteness/aws-vault/v7/config"
+	"github.com/byteness/aws-vault/v7/policy"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigDiffCommandInput contains the input for config diff.
+type ConfigDiffCommandInput struct {
+	SourceA  string // First source: local file, or oci://, git://, s3://, ssm:// ref
+	SourceB  string // Second source
+	Format   string // human, json, markdown
+	ExitCode bool   // Return a non-zero exit code when the diff is material
+
+	// For testing
+	Stdout  *os.File
+	Stderr  *os.File
+	Fetcher config.Fetcher // Override for testing oci/git/s3/ssm sources
+}
+
+// configureConfigDiffCommand sets up the diff subcommand.
+func configureConfigDiffCommand(parent *kingpin.CmdClause, app *kingpin.Application) {
+	diffInput := ConfigDiffCommandInput{}
+
+	cmd := parent.Command("diff", "Show semantic drift between two policy sources")
+
+	cmd.Arg("source-a", "First source: local file, or oci://, git://, s3://, ssm:// ref").
+		Required().
+		StringVar(&diffInput.SourceA)
+
+	cmd.Arg("source-b", "Second source: local file, or oci://, git://, s3://, ssm:// ref").
+		Required().
+		StringVar(&diffInput.SourceB)
+
+	cmd.Flag("format", "Output format: human (default), json, markdown").
+		Default("human").
+		EnumVar(&diffInput.Format, "human", "json", "markdown")
+
+	cmd.Flag("exit-code", "Return exit code 1 when material changes are detected").
+		BoolVar(&diffInput.ExitCode)
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		exitCode, err := ConfigDiffCommand(context.Background(), diffInput)
+		if err != nil {
+			app.FatalIfError(err, "config diff")
+		}
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return nil
+	})
+}
+
+// ConfigDiffCommand executes the config diff command logic. It fetches both
+// sources, parses them as the same config type, and prints their semantic
+// diff. It returns exit code 0 unless input.ExitCode is set and the diff is
+// material, or a fatal error occurs (exit code 1).
+func ConfigDiffCommand(ctx context.Context, input ConfigDiffCommandInput) (int, error) {
+	stdout := input.Stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	stderr := input.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	fetcher := input.Fetcher
+	if fetcher == nil {
+		fetcher = config.NewDefaultFetcher()
+	}
+
+	contentA, err := fetcher.Fetch(ctx, input.SourceA)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to read %s: %v\n", input.SourceA, err)
+		return 1, err
+	}
+	contentB, err := fetcher.Fetch(ctx, input.SourceB)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: failed to read %s: %v\n", input.SourceB, err)
+		return 1, err
+	}
+
+	typeA := config.DetectConfigType(contentA)
+	typeB := config.DetectConfigType(contentB)
+	if typeA != typeB {
+		err := fmt.Errorf("%s is %s but %s is %s; diff requires both sources to be the same config type", input.SourceA, typeA, input.SourceB, typeB)
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1, err
+	}
+
+	diff, err := diffSources(typeA, contentA, contentB, input.SourceA, input.SourceB)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1, err
+	}
+
+	switch strings.ToLower(input.Format) {
+	case "json":
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			fmt.Fprintf(stdout, `{"error": "failed to marshal JSON: %v"}`, err)
+			break
+		}
+		fmt.Fprintln(stdout, string(data))
+	case "markdown":
+		fmt.Fprint(stdout, config.FormatDiffMarkdown(diff))
+	default:
+		fmt.Fprint(stdout, config.FormatDiffHuman(diff))
+	}
+
+	if input.ExitCode && diff.Material() {
+		return 1, nil
+	}
+	return 0, nil
+}
+
+// diffSources parses contentA/contentB as configType and computes their
+// semantic diff. Only policy and approval documents support diffing today -
+// the other config types have no notion of "rules" to compare.
+func diffSources(configType config.ConfigType, contentA, contentB []byte, sourceA, sourceB string) (config.Diff, error) {
+	var diff config.Diff
+	switch configType {
+	case config.ConfigTypePolicy:
+		var a, b policy.Policy
+		if err := yaml.Unmarshal(contentA, &a); err != nil {
+			return diff, fmt.Errorf("failed to parse %s: %w", sourceA, err)
+		}
+		if err := yaml.Unmarshal(contentB, &b); err != nil {
+			return diff, fmt.Errorf("failed to parse %s: %w", sourceB, err)
+		}
+		diff = config.DiffPolicies(&a, &b)
+	case config.ConfigTypeApproval:
+		var a, b policy.ApprovalPolicy
+		if err := yaml.Unmarshal(contentA, &a); err != nil {
+			return diff, fmt.Errorf("failed to parse %s: %w", sourceA, err)
+		}
+		if err := yaml.Unmarshal(contentB, &b); err != nil {
+			return diff, fmt.Errorf("failed to parse %s: %w", sourceB, err)
+		}
+		diff = config.DiffApprovalPolicies(&a, &b)
+	default:
+		return diff, fmt.Errorf("config diff does not support config type %q", configType)
+	}
+	diff.SourceA = sourceA
+	diff.SourceB = sourceB
+	return diff, nil
+}
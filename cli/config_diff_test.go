@@ -0,0 +1,235 @@
+package cli
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigDiffCommand_NoChangesHumanOutput(t *testing.T) {
+	tmpDir := t.TempDir()
+	content := []byte("version: \"1\"\nrules:\n  - name: allow-dev\n    effect: allow\n    conditions:\n      profiles:\n        - dev\n")
+	pathA := filepath.Join(tmpDir, "a.yaml")
+	pathB := filepath.Join(tmpDir, "b.yaml")
+	if err := os.WriteFile(pathA, content, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(pathB, content, 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigDiffCommandInput{SourceA: pathA, SourceB: pathB, Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigDiffCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	if !strings.Contains(buf.String(), "No changes") {
+		t.Errorf("expected 'No changes', got: %s", buf.String())
+	}
+}
+
+func TestConfigDiffCommand_DetectsModifiedRule(t *testing.T) {
+	tmpDir := t.TempDir()
+	pathA := filepath.Join(tmpDir, "a.yaml")
+	pathB := filepath.Join(tmpDir, "b.yaml")
+	if err := os.WriteFile(pathA, []byte("version: \"1\"\nrules:\n  - name: prod-access\n    effect: allow\n    conditions:\n      profiles:\n        - prod\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(pathB, []byte("version: \"1\"\nrules:\n  - name: prod-access\n    effect: deny\n    conditions:\n      profiles:\n        - prod\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigDiffCommandInput{SourceA: pathA, SourceB: pathB, ExitCode: true, Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigDiffCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1 when --exit-code is set and the diff is material", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	if !strings.Contains(buf.String(), "prod-access") || !strings.Contains(buf.String(), "effect") {
+		t.Errorf("expected output to describe the effect change, got: %s", buf.String())
+	}
+}
+
+func TestConfigDiffCommand_WithoutExitCodeReturnsZero(t *testing.T) {
+	tmpDir := t.TempDir()
+	pathA := filepath.Join(tmpDir, "a.yaml")
+	pathB := filepath.Join(tmpDir, "b.yaml")
+	if err := os.WriteFile(pathA, []byte("version: \"1\"\nrules:\n  - name: r1\n    effect: allow\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(pathB, []byte("version: \"1\"\nrules:\n  - name: r1\n    effect: deny\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigDiffCommandInput{SourceA: pathA, SourceB: pathB, Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigDiffCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0 when --exit-code is not set", exitCode)
+	}
+}
+
+func TestConfigDiffCommand_MismatchedConfigTypesErrors(t *testing.T) {
+	tmpDir := t.TempDir()
+	pathA := filepath.Join(tmpDir, "a.yaml")
+	pathB := filepath.Join(tmpDir, "b.yaml")
+	if err := os.WriteFile(pathA, []byte("version: \"1\"\nrules:\n  - name: r1\n    effect: allow\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(pathB, []byte("version: \"1\"\nrules:\n  - name: r1\n    approvers:\n      - alice\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigDiffCommandInput{SourceA: pathA, SourceB: pathB, Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigDiffCommand(context.Background(), input)
+	if err == nil {
+		t.Error("expected an error for mismatched config types")
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+}
+
+func TestConfigDiffCommand_FetchesSSMSources(t *testing.T) {
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	fetcher := &fakeConfigFetcher{content: []byte("version: \"1\"\nrules:\n  - name: r1\n    effect: allow\n")}
+
+	input := ConfigDiffCommandInput{
+		SourceA: "ssm:///sentinel/policy/prod",
+		SourceB: "ssm:///sentinel/policy/prod-candidate",
+		Fetcher: fetcher,
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+
+	exitCode, err := ConfigDiffCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	if !strings.Contains(buf.String(), "No changes") {
+		t.Errorf("expected 'No changes' diffing two identical SSM sources, got: %s", buf.String())
+	}
+}
+
+func TestConfigDiffCommand_MarkdownFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	pathA := filepath.Join(tmpDir, "a.yaml")
+	pathB := filepath.Join(tmpDir, "b.yaml")
+	if err := os.WriteFile(pathA, []byte("version: \"1\"\nrules:\n  - name: r1\n    effect: allow\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+	if err := os.WriteFile(pathB, []byte("version: \"1\"\nrules:\n  - name: r1\n    effect: allow\n  - name: r2\n    effect: deny\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigDiffCommandInput{SourceA: pathA, SourceB: pathB, Format: "markdown", Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigDiffCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	if !strings.Contains(buf.String(), "| r2 | added") {
+		t.Errorf("expected a markdown table row for r2, got: %s", buf.String())
+	}
+}
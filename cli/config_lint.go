@@ -0,0 +1,308 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/alecthomas/kingpin/v2"
+	"github.com/byteness/aws-vault/v7/bootstrap"
+	"github.com/byteness/aws-vault/v7/breakglass"
+	"github.com/byteness/aws-vault/v7/config"
+	"github.com/byteness/aws-vault/v7/policy"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigLintCommandInput contains the input for config lint.
+type ConfigLintCommandInput struct {
+	Paths   []string // Local file paths to lint
+	Disable []string // Rule IDs to suppress (repeatable), e.g. SENTINEL003
+	Output  string   // human, json, sarif
+
+	// For testing
+	Stdout *os.File
+	Stderr *os.File
+}
+
+// configureConfigLintCommand sets up the lint subcommand.
+func configureConfigLintCommand(parent *kingpin.CmdClause, app *kingpin.Application) {
+	lintInput := ConfigLintCommandInput{}
+
+	cmd := parent.Command("lint", "Check configuration files for semantic and policy-quality issues")
+
+	cmd.Arg("paths", "Local files to lint").
+		StringsVar(&lintInput.Paths)
+
+	cmd.Flag("path", "Local file to lint (repeatable)").
+		Short('p').
+		StringsVar(&lintInput.Paths)
+
+	cmd.Flag("disable", "Rule ID to suppress, e.g. SENTINEL003 (repeatable)").
+		StringsVar(&lintInput.Disable)
+
+	cmd.Flag("output", "Output format: human (default), json, sarif").
+		Default("human").
+		EnumVar(&lintInput.Output, "human", "json", "sarif")
+
+	cmd.Action(func(c *kingpin.ParseContext) error {
+		exitCode, err := ConfigLintCommand(lintInput)
+		if err != nil {
+			app.FatalIfError(err, "config lint")
+		}
+		if exitCode != 0 {
+			os.Exit(exitCode)
+		}
+		return nil
+	})
+}
+
+// ConfigLintCommand executes the config lint command logic. It runs the
+// semantic/policy-quality rules (config.LintDocument) on each path
+// individually, then - if enough document types were loaded - the
+// cross-document rules (config.LintCrossDocument) once across all of them.
+// It returns exit code (0=no findings, 1=findings or error) and any fatal error.
+func ConfigLintCommand(input ConfigLintCommandInput) (int, error) {
+	stdout := input.Stdout
+	if stdout == nil {
+		stdout = os.Stdout
+	}
+	stderr := input.Stderr
+	if stderr == nil {
+		stderr = os.Stderr
+	}
+
+	if len(input.Paths) == 0 {
+		err := fmt.Errorf("no paths specified; use positional arguments or --path")
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1, err
+	}
+
+	disabled := make(map[config.LintRuleID]bool, len(input.Disable))
+	for _, id := range input.Disable {
+		disabled[config.LintRuleID(id)] = true
+	}
+
+	var results []config.LintResult
+	var cross config.CrossDocumentInputs
+
+	for _, path := range input.Paths {
+		if path == "" {
+			continue
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			results = append(results, config.LintResult{
+				Source: path,
+				Findings: []config.LintFinding{{
+					Severity: config.SeverityError,
+					Message:  fmt.Sprintf("failed to read file: %v", err),
+				}},
+			})
+			continue
+		}
+
+		ct := config.DetectConfigType(content)
+		results = append(results, config.LintDocument(ct, content, path, disabled))
+		loadCrossDocumentInput(&cross, ct, content)
+	}
+
+	if crossFindings := config.LintCrossDocument(cross, disabled); len(crossFindings) > 0 {
+		results = append(results, config.LintResult{
+			Source:   "cross-document",
+			Findings: crossFindings,
+		})
+	}
+
+	switch strings.ToLower(input.Output) {
+	case "json":
+		outputLintJSON(stdout, results)
+	case "sarif":
+		outputLintSARIF(stdout, results)
+	default:
+		outputLintHuman(stdout, results)
+	}
+
+	for _, result := range results {
+		if len(result.Findings) > 0 {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// loadCrossDocumentInput unmarshals content into the CrossDocumentInputs
+// field matching ct, if any. Unrecognized types and parse errors are
+// ignored here since config.LintDocument already reports them per-document.
+func loadCrossDocumentInput(cross *config.CrossDocumentInputs, ct config.ConfigType, content []byte) {
+	switch ct {
+	case config.ConfigTypePolicy:
+		var p policy.Policy
+		if yaml.Unmarshal(content, &p) == nil {
+			cross.Policy = &p
+		}
+	case config.ConfigTypeApproval:
+		var p policy.ApprovalPolicy
+		if yaml.Unmarshal(content, &p) == nil {
+			cross.Approval = &p
+		}
+	case config.ConfigTypeRateLimit:
+		var p breakglass.RateLimitPolicy
+		if yaml.Unmarshal(content, &p) == nil {
+			cross.RateLimit = &p
+		}
+	case config.ConfigTypeBootstrap:
+		var p bootstrap.BootstrapConfig
+		if yaml.Unmarshal(content, &p) == nil {
+			cross.Bootstrap = &p
+		}
+	}
+}
+
+// outputLintHuman outputs lint findings in human-readable format.
+func outputLintHuman(w *os.File, results []config.LintResult) {
+	total := 0
+	for _, result := range results {
+		total += len(result.Findings)
+	}
+
+	if total == 0 {
+		fmt.Fprintln(w, "No lint findings.")
+		return
+	}
+
+	for _, result := range results {
+		if len(result.Findings) == 0 {
+			continue
+		}
+		fmt.Fprintf(w, "# %s\n", result.Source)
+		for _, f := range result.Findings {
+			location := ""
+			if f.Location != "" {
+				location = f.Location + ": "
+			}
+			fmt.Fprintf(w, "  [%s/%s] %s%s\n", f.Rule, f.Severity, location, f.Message)
+		}
+		fmt.Fprintln(w)
+	}
+
+	fmt.Fprintf(w, "Summary: %d finding%s\n", total, pluralize(total))
+}
+
+// outputLintJSON outputs lint findings as JSON.
+func outputLintJSON(w *os.File, results []config.LintResult) {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		fmt.Fprintf(w, `{"error": "failed to marshal JSON: %v"}`, err)
+		return
+	}
+	fmt.Fprintln(w, string(data))
+}
+
+// sarifLog, sarifRun, sarifTool, sarifDriver, sarifRule, sarifResult, and the
+// location types are a minimal subset of the SARIF 2.1.0 schema sufficient
+// for GitHub code scanning to ingest - just enough structure to map rule IDs,
+// severities, messages, and file locations.
+type sarifLog struct {
+	Schema  string     `json:"$schema"`
+	Version string     `json:"version"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool    sarifTool     `json:"tool"`
+	Results []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name  string      `json:"name"`
+	Rules []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type sarifResult struct {
+	RuleID    string           `json:"ruleId"`
+	Level     string           `json:"level"`
+	Message   sarifMessage     `json:"message"`
+	Locations []sarifResultLoc `json:"locations"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResultLoc struct {
+	PhysicalLocation sarifPhysicalLoc `json:"physicalLocation"`
+}
+
+type sarifPhysicalLoc struct {
+	ArtifactLocation sarifArtifactLoc `json:"artifactLocation"`
+}
+
+type sarifArtifactLoc struct {
+	URI string `json:"uri"`
+}
+
+// outputLintSARIF outputs lint findings in SARIF 2.1.0 format, suitable for
+// upload to GitHub code scanning via `github/codeql-action/upload-sarif`.
+func outputLintSARIF(w *os.File, results []config.LintResult) {
+	ruleIDs := make(map[string]bool)
+	var rules []sarifRule
+	var sarifResults []sarifResult
+
+	for _, result := range results {
+		for _, f := range result.Findings {
+			id := string(f.Rule)
+			if id != "" && !ruleIDs[id] {
+				ruleIDs[id] = true
+				rules = append(rules, sarifRule{ID: id, Name: id})
+			}
+			sarifResults = append(sarifResults, sarifResult{
+				RuleID:  id,
+				Level:   sarifLevel(f.Severity),
+				Message: sarifMessage{Text: f.Message},
+				Locations: []sarifResultLoc{{
+					PhysicalLocation: sarifPhysicalLoc{
+						ArtifactLocation: sarifArtifactLoc{URI: result.Source},
+					},
+				}},
+			})
+		}
+	}
+
+	log := sarifLog{
+		Schema:  "https://raw.githubusercontent.com/oasis-tcs/sarif-spec/master/Schemata/sarif-schema-2.1.0.json",
+		Version: "2.1.0",
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:  "sentinel-config-lint",
+				Rules: rules,
+			}},
+			Results: sarifResults,
+		}},
+	}
+
+	data, err := json.MarshalIndent(log, "", "  ")
+	if err != nil {
+		fmt.Fprintf(w, `{"error": "failed to marshal SARIF: %v"}`, err)
+		return
+	}
+	fmt.Fprintln(w, string(data))
+}
+
+// sarifLevel maps a config.IssueSeverity to the SARIF result.level enum.
+func sarifLevel(severity config.IssueSeverity) string {
+	if severity == config.SeverityError {
+		return "error"
+	}
+	return "warning"
+}
@@ -0,0 +1,221 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConfigLintCommand_NoPathsReturnsError(t *testing.T) {
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigLintCommandInput{Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigLintCommand(input)
+	if err == nil {
+		t.Error("expected an error when no paths are specified")
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+}
+
+func TestConfigLintCommand_ReportsUnreachableRule(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "config-lint-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "policy.yaml")
+	content := "version: \"1\"\nrules:\n  - name: allow-all\n    effect: allow\n  - name: allow-dev\n    effect: allow\n    conditions:\n      profiles:\n        - dev\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigLintCommandInput{Paths: []string{path}, Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigLintCommand(input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	output := buf.String()
+
+	if !strings.Contains(output, "SENTINEL001") {
+		t.Errorf("expected output to mention SENTINEL001, got: %s", output)
+	}
+}
+
+func TestConfigLintCommand_DisableFlagSuppressesRule(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "config-lint-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "policy.yaml")
+	content := "version: \"1\"\nrules:\n  - name: allow-all\n    effect: allow\n  - name: allow-dev\n    effect: allow\n    conditions:\n      profiles:\n        - dev\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigLintCommandInput{
+		Paths:   []string{path},
+		Disable: []string{"SENTINEL001"},
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+
+	exitCode, err := ConfigLintCommand(input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0 when the only finding is suppressed", exitCode)
+	}
+}
+
+func TestConfigLintCommand_SARIFOutput(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "config-lint-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	path := filepath.Join(tmpDir, "policy.yaml")
+	content := "version: \"1\"\nrules:\n  - name: allow-all\n    effect: allow\n  - name: allow-dev\n    effect: allow\n    conditions:\n      profiles:\n        - dev\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigLintCommandInput{Paths: []string{path}, Output: "sarif", Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigLintCommand(input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	output := buf.String()
+
+	if !strings.Contains(output, `"ruleId": "SENTINEL001"`) {
+		t.Errorf("expected SARIF output to contain ruleId SENTINEL001, got: %s", output)
+	}
+	if !strings.Contains(output, `"$schema"`) {
+		t.Errorf("expected SARIF output to contain a $schema field, got: %s", output)
+	}
+}
+
+func TestConfigLintCommand_CrossDocumentUnusedProfile(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "config-lint-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	bootstrapPath := filepath.Join(tmpDir, "bootstrap.yaml")
+	bootstrapContent := "profiles:\n  - name: dev\n  - name: orphaned\n"
+	if err := os.WriteFile(bootstrapPath, []byte(bootstrapContent), 0644); err != nil {
+		t.Fatalf("failed to write bootstrap file: %v", err)
+	}
+
+	policyPath := filepath.Join(tmpDir, "policy.yaml")
+	policyContent := "version: \"1\"\nrules:\n  - name: allow-dev\n    effect: allow\n    conditions:\n      profiles:\n        - dev\n"
+	if err := os.WriteFile(policyPath, []byte(policyContent), 0644); err != nil {
+		t.Fatalf("failed to write policy file: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigLintCommandInput{
+		Paths:  []string{bootstrapPath, policyPath},
+		Output: "json",
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+
+	exitCode, err := ConfigLintCommand(input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	output := buf.String()
+
+	if !strings.Contains(output, "SENTINEL005") {
+		t.Errorf("expected cross-document output to mention SENTINEL005, got: %s", output)
+	}
+	if !strings.Contains(output, "cross-document") {
+		t.Errorf("expected a cross-document result source, got: %s", output)
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/byteness/aws-vault/v7/config"
 )
 
 func TestConfigValidateCommand_ValidFile(t *testing.T) {
@@ -1012,3 +1014,461 @@ func TestConfigGenerateCommand_BasicFileOutput(t *testing.T) {
 		t.Errorf("stdout should say 1 config file, got: %s", output)
 	}
 }
+
+func TestConfigMergeCommand_MergesConfDOverlay(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "config-merge-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	primaryPath := filepath.Join(tmpDir, "policy.yaml")
+	if err := os.WriteFile(primaryPath, []byte("version: \"1\"\nrules:\n  - name: base-rule\n    effect: allow\n"), 0644); err != nil {
+		t.Fatalf("failed to write primary file: %v", err)
+	}
+
+	confDDir := filepath.Join(tmpDir, "conf.d")
+	if err := os.MkdirAll(confDDir, 0755); err != nil {
+		t.Fatalf("failed to create conf.d: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(confDDir, "team-a.yaml"), []byte("rules:\n  - name: team-a-rule\n    effect: deny\n"), 0644); err != nil {
+		t.Fatalf("failed to write overlay: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigMergeCommandInput{
+		Path:   primaryPath,
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+
+	exitCode, err := ConfigMergeCommand(input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	output := buf.String()
+
+	if !strings.Contains(output, "base-rule") || !strings.Contains(output, "team-a-rule") {
+		t.Errorf("expected merged output to contain both rules, got: %s", output)
+	}
+}
+
+func TestConfigMergeCommand_ConflictReturnsNonZeroExit(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "config-merge-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	primaryPath := filepath.Join(tmpDir, "policy.yaml")
+	if err := os.WriteFile(primaryPath, []byte("version: \"1\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write primary file: %v", err)
+	}
+
+	confDDir := filepath.Join(tmpDir, "conf.d")
+	if err := os.MkdirAll(confDDir, 0755); err != nil {
+		t.Fatalf("failed to create conf.d: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(confDDir, "team-a.yaml"), []byte("version: \"2\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write overlay: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigMergeCommandInput{
+		Path:   primaryPath,
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+
+	exitCode, err := ConfigMergeCommand(input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1 for conflicting overlay", exitCode)
+	}
+
+	stderr.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stderr)
+	if !strings.Contains(buf.String(), "conflicting value") {
+		t.Errorf("expected conflict warning on stderr, got: %s", buf.String())
+	}
+}
+
+func TestConfigValidateCommand_ValidatesMergedConfDOverlay(t *testing.T) {
+	tmpDir, err := os.MkdirTemp("", "config-validate-confd-test")
+	if err != nil {
+		t.Fatalf("failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	primaryPath := filepath.Join(tmpDir, "policy.yaml")
+	if err := os.WriteFile(primaryPath, []byte("version: \"1\"\nrules:\n  - name: base-rule\n    effect: allow\n"), 0644); err != nil {
+		t.Fatalf("failed to write primary file: %v", err)
+	}
+
+	confDDir := filepath.Join(tmpDir, "conf.d")
+	if err := os.MkdirAll(confDDir, 0755); err != nil {
+		t.Fatalf("failed to create conf.d: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(confDDir, "team-a.yaml"), []byte("rules:\n  - name: team-a-rule\n    effect: deny\n"), 0644); err != nil {
+		t.Fatalf("failed to write overlay: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigValidateCommandInput{
+		Paths:  []string{primaryPath},
+		Stdout: stdout,
+		Stderr: stderr,
+	}
+
+	exitCode, err := ConfigValidateCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	output := buf.String()
+
+	if !strings.Contains(output, "(merged)") {
+		t.Errorf("expected merged config to be validated separately, got: %s", output)
+	}
+	if !strings.Contains(output, "2 valid, 0 invalid") {
+		t.Errorf("expected both the fragment-free primary and merged result to be valid, got: %s", output)
+	}
+}
+
+// fakeConfigFetcher is a stub config.Fetcher for testing remote source dispatch.
+type fakeConfigFetcher struct {
+	content []byte
+	err     error
+}
+
+func (f *fakeConfigFetcher) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	return f.content, f.err
+}
+
+// fakeConfigVerifier is a stub config.SignatureVerifier for testing --verify-signature.
+type fakeConfigVerifier struct {
+	err error
+}
+
+func (f *fakeConfigVerifier) Verify(ctx context.Context, content []byte, opts config.VerifyOptions) error {
+	return f.err
+}
+
+func TestConfigValidateCommand_FetchesOCISource(t *testing.T) {
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	fetcher := &fakeConfigFetcher{content: []byte("version: \"1\"\nrules:\n  - name: allow-all\n    effect: allow\n")}
+
+	input := ConfigValidateCommandInput{
+		Paths:   []string{"oci://registry.example.com/team/policy:v1"},
+		Fetcher: fetcher,
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+
+	exitCode, err := ConfigValidateCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+}
+
+func TestConfigValidateCommand_FetchErrorReportedAsIssue(t *testing.T) {
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	fetcher := &fakeConfigFetcher{err: errors.New("registry unreachable")}
+
+	input := ConfigValidateCommandInput{
+		Paths:   []string{"oci://registry.example.com/team/policy:v1"},
+		Fetcher: fetcher,
+		Stdout:  stdout,
+		Stderr:  stderr,
+	}
+
+	exitCode, err := ConfigValidateCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	if !strings.Contains(buf.String(), "registry unreachable") {
+		t.Errorf("expected output to mention the fetch error, got: %s", buf.String())
+	}
+}
+
+func TestConfigValidateCommand_VerifySignatureRequiresSignatureFlag(t *testing.T) {
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigValidateCommandInput{
+		Paths:           []string{"oci://registry.example.com/team/policy:v1"},
+		VerifySignature: true,
+		Stdout:          stdout,
+		Stderr:          stderr,
+	}
+
+	exitCode, err := ConfigValidateCommand(context.Background(), input)
+	if err == nil {
+		t.Error("expected an error when --verify-signature is set without --signature")
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+}
+
+func TestConfigValidateCommand_SignatureVerificationFailureReportedAsIssue(t *testing.T) {
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	fetcher := &fakeConfigFetcher{content: []byte("version: \"1\"\nrules:\n  - name: allow-all\n    effect: allow\n")}
+	verifier := &fakeConfigVerifier{err: errors.New("signature mismatch")}
+
+	input := ConfigValidateCommandInput{
+		Paths:           []string{"oci://registry.example.com/team/policy:v1"},
+		VerifySignature: true,
+		Signature:       "policy.sig",
+		PubKey:          "cosign.pub",
+		Fetcher:         fetcher,
+		Verifier:        verifier,
+		Stdout:          stdout,
+		Stderr:          stderr,
+	}
+
+	exitCode, err := ConfigValidateCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	if !strings.Contains(buf.String(), "signature verification failed") {
+		t.Errorf("expected output to mention signature verification failure, got: %s", buf.String())
+	}
+}
+
+func TestConfigValidateCommand_SignatureVerificationSuccessValidates(t *testing.T) {
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	fetcher := &fakeConfigFetcher{content: []byte("version: \"1\"\nrules:\n  - name: allow-all\n    effect: allow\n")}
+	verifier := &fakeConfigVerifier{}
+
+	input := ConfigValidateCommandInput{
+		Paths:           []string{"oci://registry.example.com/team/policy:v1"},
+		VerifySignature: true,
+		Signature:       "policy.sig",
+		PubKey:          "cosign.pub",
+		Fetcher:         fetcher,
+		Verifier:        verifier,
+		Stdout:          stdout,
+		Stderr:          stderr,
+	}
+
+	exitCode, err := ConfigValidateCommand(context.Background(), input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+}
+
+func TestConfigGenerateCommand_FromSpec(t *testing.T) {
+	dir := t.TempDir()
+	specPath := filepath.Join(dir, "spec.yaml")
+	specContent := `
+version: "1"
+template: approvals
+approval_chains:
+  - name: prod-chain
+    approvers:
+      - alice
+      - bob
+profiles:
+  - name: prod
+    users:
+      - carol
+    approval_chain: prod-chain
+`
+	if err := os.WriteFile(specPath, []byte(specContent), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigGenerateCommandInput{Spec: specPath, Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigGenerateCommand(input)
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if exitCode != 0 {
+		t.Errorf("exitCode = %d, want 0", exitCode)
+	}
+
+	stdout.Seek(0, 0)
+	var buf bytes.Buffer
+	buf.ReadFrom(stdout)
+	output := buf.String()
+
+	if !strings.Contains(output, "require_approval") {
+		t.Errorf("expected a require_approval rule, got: %s", output)
+	}
+	if !strings.Contains(output, "alice") || !strings.Contains(output, "bob") {
+		t.Errorf("expected approvers from prod-chain, got: %s", output)
+	}
+}
+
+func TestConfigGenerateCommand_SpecFileNotFoundReturnsError(t *testing.T) {
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigGenerateCommandInput{Spec: filepath.Join(t.TempDir(), "missing.yaml"), Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigGenerateCommand(input)
+	if err == nil {
+		t.Error("expected an error for a missing spec file")
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+}
+
+func TestConfigGenerateCommand_NeitherSpecNorTemplateReturnsError(t *testing.T) {
+	stdout, err := os.CreateTemp("", "stdout")
+	if err != nil {
+		t.Fatalf("failed to create stdout: %v", err)
+	}
+	defer os.Remove(stdout.Name())
+	stderr, err := os.CreateTemp("", "stderr")
+	if err != nil {
+		t.Fatalf("failed to create stderr: %v", err)
+	}
+	defer os.Remove(stderr.Name())
+
+	input := ConfigGenerateCommandInput{Stdout: stdout, Stderr: stderr}
+
+	exitCode, err := ConfigGenerateCommand(input)
+	if err == nil {
+		t.Error("expected an error when neither --spec nor --template/--profile are given")
+	}
+	if exitCode != 1 {
+		t.Errorf("exitCode = %d, want 1", exitCode)
+	}
+}
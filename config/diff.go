@@ -0,0 +1,217 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/byteness/aws-vault/v7/policy"
+)
+
+// FieldChange is a single before/after change within a modified rule.
+type FieldChange struct {
+	Field  string `json:"field"`
+	Before string `json:"before"`
+	After  string `json:"after"`
+}
+
+// RuleDiff describes how a single rule, matched by name across both
+// sources, changed.
+type RuleDiff struct {
+	Name    string        `json:"name"`
+	Changes []FieldChange `json:"changes"`
+}
+
+// Diff is the semantic diff between two config documents of the same type.
+// It compares the effective, parsed policy rather than raw text, so
+// reordering fragments or reformatting YAML produces no noise.
+type Diff struct {
+	ConfigType    ConfigType `json:"config_type"`
+	SourceA       string     `json:"source_a"`
+	SourceB       string     `json:"source_b"`
+	RulesAdded    []string   `json:"rules_added,omitempty"`
+	RulesRemoved  []string   `json:"rules_removed,omitempty"`
+	RulesModified []RuleDiff `json:"rules_modified,omitempty"`
+}
+
+// Material reports whether the diff contains any change at all - CI jobs
+// use this to decide whether a policy change needs human review.
+func (d Diff) Material() bool {
+	return len(d.RulesAdded) > 0 || len(d.RulesRemoved) > 0 || len(d.RulesModified) > 0
+}
+
+// DiffPolicies computes the semantic diff between two access policies,
+// matching rules by name and comparing effect, profile and user conditions,
+// reason, and max server duration. Time-window and mode conditions are not
+// compared.
+func DiffPolicies(a, b *policy.Policy) Diff {
+	before := indexPolicyRules(a)
+	after := indexPolicyRules(b)
+
+	diff := Diff{ConfigType: ConfigTypePolicy}
+	for _, name := range sortedNamesNotIn(after, before) {
+		diff.RulesAdded = append(diff.RulesAdded, name)
+	}
+	for _, name := range sortedNamesNotIn(before, after) {
+		diff.RulesRemoved = append(diff.RulesRemoved, name)
+	}
+	for _, name := range sortedCommonNames(before, after) {
+		oldRule, newRule := before[name], after[name]
+		var changes []FieldChange
+		if oldRule.Effect != newRule.Effect {
+			changes = append(changes, FieldChange{Field: "effect", Before: string(oldRule.Effect), After: string(newRule.Effect)})
+		}
+		if !stringSetsEqual(oldRule.Conditions.Profiles, newRule.Conditions.Profiles) {
+			changes = append(changes, FieldChange{Field: "conditions.profiles", Before: joinOrAll(oldRule.Conditions.Profiles), After: joinOrAll(newRule.Conditions.Profiles)})
+		}
+		if !stringSetsEqual(oldRule.Conditions.Users, newRule.Conditions.Users) {
+			changes = append(changes, FieldChange{Field: "conditions.users", Before: joinOrAll(oldRule.Conditions.Users), After: joinOrAll(newRule.Conditions.Users)})
+		}
+		if oldRule.Reason != newRule.Reason {
+			changes = append(changes, FieldChange{Field: "reason", Before: oldRule.Reason, After: newRule.Reason})
+		}
+		if oldRule.MaxServerDuration != newRule.MaxServerDuration {
+			changes = append(changes, FieldChange{Field: "max_server_duration", Before: oldRule.MaxServerDuration.String(), After: newRule.MaxServerDuration.String()})
+		}
+		if len(changes) > 0 {
+			diff.RulesModified = append(diff.RulesModified, RuleDiff{Name: name, Changes: changes})
+		}
+	}
+	return diff
+}
+
+// DiffApprovalPolicies computes the semantic diff between two approval
+// policies, matching rules by name and comparing profiles and approvers.
+func DiffApprovalPolicies(a, b *policy.ApprovalPolicy) Diff {
+	before := indexApprovalRules(a)
+	after := indexApprovalRules(b)
+
+	diff := Diff{ConfigType: ConfigTypeApproval}
+	for _, name := range sortedNamesNotIn(after, before) {
+		diff.RulesAdded = append(diff.RulesAdded, name)
+	}
+	for _, name := range sortedNamesNotIn(before, after) {
+		diff.RulesRemoved = append(diff.RulesRemoved, name)
+	}
+	for _, name := range sortedCommonNames(before, after) {
+		oldRule, newRule := before[name], after[name]
+		var changes []FieldChange
+		if !stringSetsEqual(oldRule.Profiles, newRule.Profiles) {
+			changes = append(changes, FieldChange{Field: "profiles", Before: joinOrAll(oldRule.Profiles), After: joinOrAll(newRule.Profiles)})
+		}
+		if !stringSetsEqual(oldRule.Approvers, newRule.Approvers) {
+			changes = append(changes, FieldChange{Field: "approvers", Before: joinOrAll(oldRule.Approvers), After: joinOrAll(newRule.Approvers)})
+		}
+		if len(changes) > 0 {
+			diff.RulesModified = append(diff.RulesModified, RuleDiff{Name: name, Changes: changes})
+		}
+	}
+	return diff
+}
+
+func indexPolicyRules(p *policy.Policy) map[string]policy.Rule {
+	idx := make(map[string]policy.Rule)
+	if p == nil {
+		return idx
+	}
+	for _, r := range p.Rules {
+		idx[r.Name] = r
+	}
+	return idx
+}
+
+func indexApprovalRules(p *policy.ApprovalPolicy) map[string]policy.ApprovalRule {
+	idx := make(map[string]policy.ApprovalRule)
+	if p == nil {
+		return idx
+	}
+	for _, r := range p.Rules {
+		idx[r.Name] = r
+	}
+	return idx
+}
+
+// sortedNamesNotIn returns the keys of present that aren't in absent, sorted
+// for stable output.
+func sortedNamesNotIn[T any](present, absent map[string]T) []string {
+	var names []string
+	for name := range present {
+		if _, ok := absent[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// sortedCommonNames returns the keys present in both maps, sorted for stable output.
+func sortedCommonNames[T any](a, b map[string]T) []string {
+	var names []string
+	for name := range a {
+		if _, ok := b[name]; ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// joinOrAll renders a profiles/users/approvers list for display, since an
+// empty list means "all" rather than "none".
+func joinOrAll(values []string) string {
+	if len(values) == 0 {
+		return "(all)"
+	}
+	return strings.Join(values, ", ")
+}
+
+// FormatDiffHuman renders a Diff as human-readable text.
+func FormatDiffHuman(d Diff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Diff: %s (%s) vs %s\n", d.SourceA, d.ConfigType, d.SourceB)
+
+	if !d.Material() {
+		b.WriteString("  No changes\n")
+		return b.String()
+	}
+
+	for _, name := range d.RulesAdded {
+		fmt.Fprintf(&b, "  + rule %q added\n", name)
+	}
+	for _, name := range d.RulesRemoved {
+		fmt.Fprintf(&b, "  - rule %q removed\n", name)
+	}
+	for _, rd := range d.RulesModified {
+		fmt.Fprintf(&b, "  ~ rule %q modified\n", rd.Name)
+		for _, c := range rd.Changes {
+			fmt.Fprintf(&b, "      %s: %q -> %q\n", c.Field, c.Before, c.After)
+		}
+	}
+	return b.String()
+}
+
+// FormatDiffMarkdown renders a Diff as a markdown table suitable for a PR comment.
+func FormatDiffMarkdown(d Diff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "### Config diff: `%s` vs `%s` (%s)\n\n", d.SourceA, d.SourceB, d.ConfigType)
+
+	if !d.Material() {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+
+	b.WriteString("| Rule | Change | Field | Before | After |\n")
+	b.WriteString("|------|--------|-------|--------|-------|\n")
+	for _, name := range d.RulesAdded {
+		fmt.Fprintf(&b, "| %s | added | | | |\n", name)
+	}
+	for _, name := range d.RulesRemoved {
+		fmt.Fprintf(&b, "| %s | removed | | | |\n", name)
+	}
+	for _, rd := range d.RulesModified {
+		for _, c := range rd.Changes {
+			fmt.Fprintf(&b, "| %s | modified | %s | %s | %s |\n", rd.Name, c.Field, c.Before, c.After)
+		}
+	}
+	return b.String()
+}
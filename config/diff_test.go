@@ -0,0 +1,144 @@
+package config
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/byteness/aws-vault/v7/policy"
+)
+
+func TestDiffPolicies_DetectsAddedAndRemovedRules(t *testing.T) {
+	a := &policy.Policy{Rules: []policy.Rule{
+		{Name: "allow-dev", Effect: policy.EffectAllow},
+	}}
+	b := &policy.Policy{Rules: []policy.Rule{
+		{Name: "allow-prod", Effect: policy.EffectAllow},
+	}}
+
+	diff := DiffPolicies(a, b)
+
+	if len(diff.RulesRemoved) != 1 || diff.RulesRemoved[0] != "allow-dev" {
+		t.Errorf("expected allow-dev removed, got %v", diff.RulesRemoved)
+	}
+	if len(diff.RulesAdded) != 1 || diff.RulesAdded[0] != "allow-prod" {
+		t.Errorf("expected allow-prod added, got %v", diff.RulesAdded)
+	}
+	if !diff.Material() {
+		t.Error("expected Material() to be true")
+	}
+}
+
+func TestDiffPolicies_DetectsModifiedEffect(t *testing.T) {
+	a := &policy.Policy{Rules: []policy.Rule{
+		{Name: "prod-access", Effect: policy.EffectAllow, Conditions: policy.Condition{Profiles: []string{"prod"}}},
+	}}
+	b := &policy.Policy{Rules: []policy.Rule{
+		{Name: "prod-access", Effect: policy.EffectDeny, Conditions: policy.Condition{Profiles: []string{"prod"}}},
+	}}
+
+	diff := DiffPolicies(a, b)
+
+	if len(diff.RulesModified) != 1 {
+		t.Fatalf("expected 1 modified rule, got %d", len(diff.RulesModified))
+	}
+	mod := diff.RulesModified[0]
+	if mod.Name != "prod-access" {
+		t.Errorf("name = %q, want prod-access", mod.Name)
+	}
+	found := false
+	for _, c := range mod.Changes {
+		if c.Field == "effect" && c.Before == "allow" && c.After == "deny" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an effect change, got %v", mod.Changes)
+	}
+}
+
+func TestDiffPolicies_DetectsProfileChange(t *testing.T) {
+	a := &policy.Policy{Rules: []policy.Rule{
+		{Name: "r1", Effect: policy.EffectAllow, Conditions: policy.Condition{Profiles: []string{"dev"}}},
+	}}
+	b := &policy.Policy{Rules: []policy.Rule{
+		{Name: "r1", Effect: policy.EffectAllow, Conditions: policy.Condition{Profiles: []string{"dev", "staging"}}},
+	}}
+
+	diff := DiffPolicies(a, b)
+
+	if len(diff.RulesModified) != 1 {
+		t.Fatalf("expected 1 modified rule, got %d", len(diff.RulesModified))
+	}
+	found := false
+	for _, c := range diff.RulesModified[0].Changes {
+		if c.Field == "conditions.profiles" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected a conditions.profiles change, got %v", diff.RulesModified[0].Changes)
+	}
+}
+
+func TestDiffPolicies_IdenticalRulesProduceNoDiff(t *testing.T) {
+	a := &policy.Policy{Rules: []policy.Rule{
+		{Name: "r1", Effect: policy.EffectAllow, Conditions: policy.Condition{Profiles: []string{"dev"}}, MaxServerDuration: time.Hour},
+	}}
+	b := &policy.Policy{Rules: []policy.Rule{
+		{Name: "r1", Effect: policy.EffectAllow, Conditions: policy.Condition{Profiles: []string{"dev"}}, MaxServerDuration: time.Hour},
+	}}
+
+	diff := DiffPolicies(a, b)
+
+	if diff.Material() {
+		t.Errorf("expected no material diff, got %+v", diff)
+	}
+}
+
+func TestDiffApprovalPolicies_DetectsApproverChange(t *testing.T) {
+	a := &policy.ApprovalPolicy{Rules: []policy.ApprovalRule{
+		{Name: "prod-approval", Profiles: []string{"prod"}, Approvers: []string{"alice"}},
+	}}
+	b := &policy.ApprovalPolicy{Rules: []policy.ApprovalRule{
+		{Name: "prod-approval", Profiles: []string{"prod"}, Approvers: []string{"alice", "bob"}},
+	}}
+
+	diff := DiffApprovalPolicies(a, b)
+
+	if len(diff.RulesModified) != 1 {
+		t.Fatalf("expected 1 modified rule, got %d", len(diff.RulesModified))
+	}
+	found := false
+	for _, c := range diff.RulesModified[0].Changes {
+		if c.Field == "approvers" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected an approvers change, got %v", diff.RulesModified[0].Changes)
+	}
+}
+
+func TestFormatDiffHuman_NoChangesMessage(t *testing.T) {
+	diff := Diff{ConfigType: ConfigTypePolicy, SourceA: "a.yaml", SourceB: "b.yaml"}
+
+	output := FormatDiffHuman(diff)
+	if !strings.Contains(output, "No changes") {
+		t.Errorf("expected 'No changes', got: %s", output)
+	}
+}
+
+func TestFormatDiffMarkdown_RendersTable(t *testing.T) {
+	diff := Diff{
+		ConfigType: ConfigTypePolicy,
+		SourceA:    "a.yaml",
+		SourceB:    "b.yaml",
+		RulesAdded: []string{"new-rule"},
+	}
+
+	output := FormatDiffMarkdown(diff)
+	if !strings.Contains(output, "| new-rule | added") {
+		t.Errorf("expected a markdown table row for new-rule, got: %s", output)
+	}
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// SourceScheme identifies which backend a config source reference resolves
+// through. Local paths have no scheme and are read directly; everything else
+// is dispatched to a Fetcher.
+type SourceScheme string
+
+const (
+	SchemeLocal SourceScheme = "local"
+	SchemeOCI   SourceScheme = "oci"
+	SchemeGit   SourceScheme = "git"
+	SchemeS3    SourceScheme = "s3"
+	SchemeSSM   SourceScheme = "ssm"
+)
+
+// Fetcher retrieves the raw bytes of a config document from a remote source.
+// ref is the full source reference including its scheme prefix
+// (e.g. "oci://registry/repo:tag").
+type Fetcher interface {
+	Fetch(ctx context.Context, ref string) ([]byte, error)
+}
+
+// ParseSourceRef splits a source reference into its scheme and the
+// scheme-specific remainder. A reference with no recognized "scheme://"
+// prefix is treated as a local file path.
+func ParseSourceRef(ref string) (SourceScheme, string) {
+	for _, scheme := range []SourceScheme{SchemeOCI, SchemeGit, SchemeS3, SchemeSSM} {
+		prefix := string(scheme) + "://"
+		if strings.HasPrefix(ref, prefix) {
+			return scheme, strings.TrimPrefix(ref, prefix)
+		}
+	}
+	return SchemeLocal, ref
+}
+
+// DefaultFetcher dispatches a source reference to the Fetcher registered for
+// its scheme. Local references are read directly from disk rather than
+// through a Fetcher.
+type DefaultFetcher struct {
+	OCI Fetcher
+	Git Fetcher
+	S3  Fetcher
+	SSM Fetcher
+}
+
+// NewDefaultFetcher wires up the built-in OCI, git, S3, and SSM backends.
+func NewDefaultFetcher() *DefaultFetcher {
+	return &DefaultFetcher{
+		OCI: NewOCIFetcher(),
+		Git: NewGitFetcher(),
+		S3:  NewS3Fetcher(),
+		SSM: NewSSMFetcher(),
+	}
+}
+
+// Fetch reads ref's content regardless of scheme, reading local files
+// directly and dispatching everything else to the matching backend.
+func (f *DefaultFetcher) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	scheme, _ := ParseSourceRef(ref)
+	switch scheme {
+	case SchemeLocal:
+		return os.ReadFile(ref)
+	case SchemeOCI:
+		return f.OCI.Fetch(ctx, ref)
+	case SchemeGit:
+		return f.Git.Fetch(ctx, ref)
+	case SchemeS3:
+		return f.S3.Fetch(ctx, ref)
+	case SchemeSSM:
+		return f.SSM.Fetch(ctx, ref)
+	default:
+		return nil, fmt.Errorf("unsupported source scheme: %s", scheme)
+	}
+}
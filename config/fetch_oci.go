@@ -0,0 +1,174 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// httpDoer is the subset of *http.Client used by OCIFetcher, so tests can
+// inject a fake transport without a real registry.
+type httpDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// ociManifest is the minimal subset of the OCI image manifest schema needed
+// to locate the config layer's blob digest.
+type ociManifest struct {
+	Layers []struct {
+		MediaType string `json:"mediaType"`
+		Digest    string `json:"digest"`
+		Size      int64  `json:"size"`
+	} `json:"layers"`
+}
+
+// OCIFetcher retrieves a config document published as the single layer of an
+// OCI artifact, e.g. a cosign-signed policy bundle pushed with `oras push`.
+type OCIFetcher struct {
+	client httpDoer
+}
+
+// NewOCIFetcher creates an OCIFetcher using the default HTTP client.
+func NewOCIFetcher() *OCIFetcher {
+	return &OCIFetcher{client: http.DefaultClient}
+}
+
+// NewOCIFetcherWithClient creates an OCIFetcher with a custom HTTP client for testing.
+func NewOCIFetcherWithClient(client httpDoer) *OCIFetcher {
+	return &OCIFetcher{client: client}
+}
+
+// Fetch resolves an "oci://registry/repo:tag" reference to the raw bytes of
+// its single config layer, following the standard OCI distribution API:
+// fetch the manifest, then the blob it points at.
+func (f *OCIFetcher) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	_, rest := ParseSourceRef(ref)
+	registry, repo, tag, err := splitOCIRef(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	manifestURL := fmt.Sprintf("https://%s/v2/%s/manifests/%s", registry, repo, tag)
+	manifestBody, err := f.get(ctx, registry, repo, manifestURL, "application/vnd.oci.image.manifest.v1+json")
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch manifest for %s: %w", ref, err)
+	}
+
+	var manifest ociManifest
+	if err := json.Unmarshal(manifestBody, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse manifest for %s: %w", ref, err)
+	}
+	if len(manifest.Layers) == 0 {
+		return nil, fmt.Errorf("manifest for %s has no layers", ref)
+	}
+
+	blobURL := fmt.Sprintf("https://%s/v2/%s/blobs/%s", registry, repo, manifest.Layers[0].Digest)
+	return f.get(ctx, registry, repo, blobURL, "")
+}
+
+// get performs an authenticated GET, transparently handling the OCI
+// distribution spec's Www-Authenticate token challenge on a 401 response.
+func (f *OCIFetcher) get(ctx context.Context, registry, repo, url, accept string) ([]byte, error) {
+	resp, err := f.doGet(ctx, url, accept, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusUnauthorized {
+		token, tokenErr := f.fetchBearerToken(ctx, resp.Header.Get("Www-Authenticate"), repo)
+		if tokenErr != nil {
+			return nil, fmt.Errorf("failed to obtain registry token: %w", tokenErr)
+		}
+		resp, err = f.doGet(ctx, url, accept, token)
+		if err != nil {
+			return nil, err
+		}
+		defer resp.Body.Close()
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("registry %s returned %s for %s", registry, resp.Status, url)
+	}
+	return io.ReadAll(resp.Body)
+}
+
+func (f *OCIFetcher) doGet(ctx context.Context, url, accept, bearerToken string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if bearerToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+	}
+	return f.client.Do(req)
+}
+
+// wwwAuthenticateParam extracts a single key="value" pair from a
+// Www-Authenticate header such as `Bearer realm="...",service="...",scope="..."`.
+var wwwAuthenticateParam = regexp.MustCompile(`(\w+)="([^"]*)"`)
+
+// fetchBearerToken requests an anonymous pull token from the realm named in
+// the registry's Www-Authenticate challenge.
+func (f *OCIFetcher) fetchBearerToken(ctx context.Context, challenge, repo string) (string, error) {
+	params := make(map[string]string)
+	for _, m := range wwwAuthenticateParam.FindAllStringSubmatch(challenge, -1) {
+		params[m[1]] = m[2]
+	}
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("no realm in Www-Authenticate header: %q", challenge)
+	}
+
+	tokenURL := fmt.Sprintf("%s?service=%s&scope=repository:%s:pull", realm, params["service"], repo)
+	resp, err := f.doGet(ctx, tokenURL, "", "")
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("token endpoint returned %s", resp.Status)
+	}
+
+	var tokenResp struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&tokenResp); err != nil {
+		return "", err
+	}
+	if tokenResp.Token != "" {
+		return tokenResp.Token, nil
+	}
+	return tokenResp.AccessToken, nil
+}
+
+// splitOCIRef parses "registry/repo:tag" into its three parts. tag defaults
+// to "latest" if omitted.
+func splitOCIRef(rest string) (registry, repo, tag string, err error) {
+	slash := strings.Index(rest, "/")
+	if slash == -1 {
+		return "", "", "", fmt.Errorf("invalid oci reference %q: missing repository", rest)
+	}
+	registry = rest[:slash]
+	repoAndTag := rest[slash+1:]
+
+	tag = "latest"
+	if colon := strings.LastIndex(repoAndTag, ":"); colon != -1 {
+		repo = repoAndTag[:colon]
+		tag = repoAndTag[colon+1:]
+	} else {
+		repo = repoAndTag
+	}
+	if repo == "" {
+		return "", "", "", fmt.Errorf("invalid oci reference %q: missing repository", rest)
+	}
+	return registry, repo, tag, nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// s3API is the subset of the S3 client S3Fetcher uses.
+type s3API interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+}
+
+// S3Fetcher retrieves a config document from an S3 object.
+// References look like "s3://bucket/key".
+type S3Fetcher struct {
+	client s3API
+}
+
+// NewS3Fetcher creates an S3Fetcher. The S3 client is lazily constructed
+// from the default AWS config on first Fetch, since loading credentials
+// eagerly would slow down validate/lint runs that never touch S3.
+func NewS3Fetcher() *S3Fetcher {
+	return &S3Fetcher{}
+}
+
+// NewS3FetcherWithClient creates an S3Fetcher with a custom client for testing.
+func NewS3FetcherWithClient(client s3API) *S3Fetcher {
+	return &S3Fetcher{client: client}
+}
+
+// Fetch downloads the object named by ref.
+func (f *S3Fetcher) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	_, rest := ParseSourceRef(ref)
+	bucket, key, err := splitS3Ref(rest)
+	if err != nil {
+		return nil, err
+	}
+
+	client := f.client
+	if client == nil {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		client = s3.NewFromConfig(awsCfg)
+	}
+
+	out, err := client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get s3://%s/%s: %w", bucket, key, err)
+	}
+	defer out.Body.Close()
+
+	return io.ReadAll(out.Body)
+}
+
+// splitS3Ref parses "bucket/key" into its two parts.
+func splitS3Ref(rest string) (bucket, key string, err error) {
+	slash := strings.Index(rest, "/")
+	if slash == -1 {
+		return "", "", fmt.Errorf("invalid s3 reference %q: missing key", rest)
+	}
+	bucket = rest[:slash]
+	key = rest[slash+1:]
+	if bucket == "" || key == "" {
+		return "", "", fmt.Errorf("invalid s3 reference %q: bucket and key are required", rest)
+	}
+	return bucket, key, nil
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+)
+
+// ssmAPI is the subset of the SSM client SSMFetcher uses.
+type ssmAPI interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// SSMFetcher retrieves a config document from an SSM parameter's value.
+// References look like "ssm:///my/parameter/path".
+type SSMFetcher struct {
+	client ssmAPI
+}
+
+// NewSSMFetcher creates an SSMFetcher. The SSM client is lazily constructed
+// from the default AWS config on first Fetch, since loading credentials
+// eagerly would slow down validate/lint/diff runs that never touch SSM.
+func NewSSMFetcher() *SSMFetcher {
+	return &SSMFetcher{}
+}
+
+// NewSSMFetcherWithClient creates an SSMFetcher with a custom client for testing.
+func NewSSMFetcherWithClient(client ssmAPI) *SSMFetcher {
+	return &SSMFetcher{client: client}
+}
+
+// Fetch reads the decrypted value of the SSM parameter named by ref.
+func (f *SSMFetcher) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	_, path := ParseSourceRef(ref)
+
+	client := f.client
+	if client == nil {
+		awsCfg, err := awsconfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config: %w", err)
+		}
+		client = ssm.NewFromConfig(awsCfg)
+	}
+
+	out, err := client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(path),
+		WithDecryption: aws.Bool(true),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ssm parameter %s: %w", path, err)
+	}
+	if out.Parameter == nil || out.Parameter.Value == nil {
+		return nil, fmt.Errorf("ssm parameter %s has no value", path)
+	}
+
+	return []byte(*out.Parameter.Value), nil
+}
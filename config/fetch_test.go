@@ -0,0 +1,407 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+func TestParseSourceRef(t *testing.T) {
+	tests := []struct {
+		ref        string
+		wantScheme SourceScheme
+		wantRest   string
+	}{
+		{"policy.yaml", SchemeLocal, "policy.yaml"},
+		{"/abs/path/policy.yaml", SchemeLocal, "/abs/path/policy.yaml"},
+		{"oci://registry.example.com/team/policy:v1", SchemeOCI, "registry.example.com/team/policy:v1"},
+		{"git://github.com/org/repo//policy/prod.yaml@main", SchemeGit, "github.com/org/repo//policy/prod.yaml@main"},
+		{"s3://my-bucket/policies/prod.yaml", SchemeS3, "my-bucket/policies/prod.yaml"},
+		{"ssm:///sentinel/policy/prod", SchemeSSM, "/sentinel/policy/prod"},
+	}
+
+	for _, tt := range tests {
+		scheme, rest := ParseSourceRef(tt.ref)
+		if scheme != tt.wantScheme || rest != tt.wantRest {
+			t.Errorf("ParseSourceRef(%q) = (%q, %q), want (%q, %q)", tt.ref, scheme, rest, tt.wantScheme, tt.wantRest)
+		}
+	}
+}
+
+func TestSplitOCIRef(t *testing.T) {
+	registry, repo, tag, err := splitOCIRef("registry.example.com/team/policy:v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if registry != "registry.example.com" || repo != "team/policy" || tag != "v1" {
+		t.Errorf("got (%q, %q, %q)", registry, repo, tag)
+	}
+}
+
+func TestSplitOCIRef_DefaultsToLatestTag(t *testing.T) {
+	_, _, tag, err := splitOCIRef("registry.example.com/team/policy")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tag != "latest" {
+		t.Errorf("tag = %q, want latest", tag)
+	}
+}
+
+func TestSplitOCIRef_MissingRepoErrors(t *testing.T) {
+	if _, _, _, err := splitOCIRef("registry.example.com"); err == nil {
+		t.Error("expected an error for a ref with no repository")
+	}
+}
+
+func TestSplitGitRef(t *testing.T) {
+	repoURL, path, ref, err := splitGitRef("github.com/org/repo//policy/prod.yaml@main")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repoURL != "https://github.com/org/repo" || path != "policy/prod.yaml" || ref != "main" {
+		t.Errorf("got (%q, %q, %q)", repoURL, path, ref)
+	}
+}
+
+func TestSplitGitRef_NoRefDefaultsEmpty(t *testing.T) {
+	_, _, ref, err := splitGitRef("github.com/org/repo//policy/prod.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ref != "" {
+		t.Errorf("ref = %q, want empty", ref)
+	}
+}
+
+func TestSplitGitRef_MissingPathSeparatorErrors(t *testing.T) {
+	if _, _, _, err := splitGitRef("github.com/org/repo@main"); err == nil {
+		t.Error("expected an error for a ref with no //path")
+	}
+}
+
+func TestSplitS3Ref(t *testing.T) {
+	bucket, key, err := splitS3Ref("my-bucket/policies/prod.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if bucket != "my-bucket" || key != "policies/prod.yaml" {
+		t.Errorf("got (%q, %q)", bucket, key)
+	}
+}
+
+func TestSplitS3Ref_MissingKeyErrors(t *testing.T) {
+	if _, _, err := splitS3Ref("my-bucket"); err == nil {
+		t.Error("expected an error for a ref with no key")
+	}
+}
+
+// fakeFetcher is a stub Fetcher for testing DefaultFetcher's dispatch.
+type fakeFetcher struct {
+	content []byte
+	err     error
+}
+
+func (f *fakeFetcher) Fetch(ctx context.Context, ref string) ([]byte, error) {
+	return f.content, f.err
+}
+
+func TestDefaultFetcher_DispatchesByScheme(t *testing.T) {
+	ociFetcher := &fakeFetcher{content: []byte("oci-content")}
+	gitFetcher := &fakeFetcher{content: []byte("git-content")}
+	s3Fetcher := &fakeFetcher{content: []byte("s3-content")}
+	ssmFetcher := &fakeFetcher{content: []byte("ssm-content")}
+	fetcher := &DefaultFetcher{OCI: ociFetcher, Git: gitFetcher, S3: s3Fetcher, SSM: ssmFetcher}
+
+	tests := []struct {
+		ref  string
+		want string
+	}{
+		{"oci://registry/repo:tag", "oci-content"},
+		{"git://host/repo//path@ref", "git-content"},
+		{"s3://bucket/key", "s3-content"},
+		{"ssm:///sentinel/policy/prod", "ssm-content"},
+	}
+	for _, tt := range tests {
+		got, err := fetcher.Fetch(context.Background(), tt.ref)
+		if err != nil {
+			t.Errorf("Fetch(%q) unexpected error: %v", tt.ref, err)
+		}
+		if string(got) != tt.want {
+			t.Errorf("Fetch(%q) = %q, want %q", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestDefaultFetcher_LocalReadsFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := filepath.Join(tmpDir, "policy.yaml")
+	if err := os.WriteFile(path, []byte("version: \"1\"\n"), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+
+	fetcher := &DefaultFetcher{}
+	got, err := fetcher.Fetch(context.Background(), path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(got) != "version: \"1\"\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+// fakeHTTPDoer serves canned responses keyed by URL for OCIFetcher tests.
+type fakeHTTPDoer struct {
+	responses map[string]*http.Response
+}
+
+func (f *fakeHTTPDoer) Do(req *http.Request) (*http.Response, error) {
+	resp, ok := f.responses[req.URL.String()]
+	if !ok {
+		return nil, errors.New("no fake response registered for " + req.URL.String())
+	}
+	return resp, nil
+}
+
+func newFakeResponse(status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Status:     http.StatusText(status),
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+func TestOCIFetcher_Fetch_ManifestAndBlob(t *testing.T) {
+	manifestJSON := `{"layers":[{"mediaType":"application/yaml","digest":"sha256:abc","size":10}]}`
+	doer := &fakeHTTPDoer{responses: map[string]*http.Response{
+		"https://registry.example.com/v2/team/policy/manifests/v1":     newFakeResponse(http.StatusOK, manifestJSON),
+		"https://registry.example.com/v2/team/policy/blobs/sha256:abc": newFakeResponse(http.StatusOK, "version: \"1\"\n"),
+	}}
+
+	fetcher := NewOCIFetcherWithClient(doer)
+	content, err := fetcher.Fetch(context.Background(), "oci://registry.example.com/team/policy:v1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != "version: \"1\"\n" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestOCIFetcher_Fetch_NoLayersErrors(t *testing.T) {
+	doer := &fakeHTTPDoer{responses: map[string]*http.Response{
+		"https://registry.example.com/v2/team/policy/manifests/v1": newFakeResponse(http.StatusOK, `{"layers":[]}`),
+	}}
+
+	fetcher := NewOCIFetcherWithClient(doer)
+	if _, err := fetcher.Fetch(context.Background(), "oci://registry.example.com/team/policy:v1"); err == nil {
+		t.Error("expected an error for a manifest with no layers")
+	}
+}
+
+// fakeCommandRunner records invocations and returns canned output for
+// GitFetcher and CosignVerifier tests.
+type fakeCommandRunner struct {
+	output []byte
+	err    error
+	calls  [][]string
+}
+
+func (f *fakeCommandRunner) Run(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	f.calls = append(f.calls, append([]string{name}, args...))
+	return f.output, f.err
+}
+
+func TestGitFetcher_Fetch_ClonesAndReadsPath(t *testing.T) {
+	// writingCommandRunner fakes `git clone` by writing the expected file
+	// into the temp dir GitFetcher created, since Fetch reads it back with
+	// os.ReadFile once the (faked) clone "succeeds".
+	runner := &writingCommandRunner{content: []byte("version: \"1\"\n"), path: "policy/prod.yaml"}
+	fetcher := NewGitFetcherWithRunner(runner)
+
+	content, err := fetcher.Fetch(context.Background(), "git://github.com/org/repo//policy/prod.yaml@main")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != "version: \"1\"\n" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+// writingCommandRunner fakes `git clone` by writing a file into the target
+// directory passed as the clone's last argument, and records every command
+// it was asked to run so tests can assert on the clone/checkout strategy.
+type writingCommandRunner struct {
+	content []byte
+	path    string
+	calls   [][]string
+}
+
+func (w *writingCommandRunner) Run(ctx context.Context, dir, name string, args ...string) ([]byte, error) {
+	w.calls = append(w.calls, append([]string{name}, args...))
+	if name != "git" || len(args) == 0 || args[0] != "clone" {
+		return nil, nil
+	}
+	target := args[len(args)-1]
+	fullPath := filepath.Join(target, w.path)
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(fullPath, w.content, 0644); err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
+func TestGitFetcher_Fetch_BranchUsesShallowClone(t *testing.T) {
+	runner := &writingCommandRunner{content: []byte("version: \"1\"\n"), path: "policy/prod.yaml"}
+	fetcher := NewGitFetcherWithRunner(runner)
+
+	if _, err := fetcher.Fetch(context.Background(), "git://github.com/org/repo//policy/prod.yaml@main"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runner.calls) != 1 {
+		t.Fatalf("expected 1 git invocation for a branch ref, got %d: %v", len(runner.calls), runner.calls)
+	}
+	if !contains(runner.calls[0], "--depth") || !contains(runner.calls[0], "--branch") {
+		t.Errorf("expected a shallow clone with --branch, got %v", runner.calls[0])
+	}
+}
+
+func TestGitFetcher_Fetch_CommitSHAUsesFullCloneAndCheckout(t *testing.T) {
+	sha := "a1b2c3d4e5f60718293a4b5c6d7e8f9012345678"
+	runner := &writingCommandRunner{content: []byte("version: \"1\"\n"), path: "policy/prod.yaml"}
+	fetcher := NewGitFetcherWithRunner(runner)
+
+	if _, err := fetcher.Fetch(context.Background(), "git://github.com/org/repo//policy/prod.yaml@"+sha); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(runner.calls) != 2 {
+		t.Fatalf("expected a clone followed by a checkout, got %d calls: %v", len(runner.calls), runner.calls)
+	}
+	if contains(runner.calls[0], "--depth") || contains(runner.calls[0], "--branch") {
+		t.Errorf("expected a full clone for a commit SHA, got %v", runner.calls[0])
+	}
+	if runner.calls[1][1] != "checkout" || runner.calls[1][len(runner.calls[1])-1] != sha {
+		t.Errorf("expected a checkout of %s, got %v", sha, runner.calls[1])
+	}
+}
+
+func contains(args []string, s string) bool {
+	for _, a := range args {
+		if a == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestLooksLikeCommitSHA(t *testing.T) {
+	tests := []struct {
+		ref  string
+		want bool
+	}{
+		{"main", false},
+		{"v1.2.3", false},
+		{"a1b2c3d", true},
+		{"a1b2c3d4e5f60718293a4b5c6d7e8f9012345678", true},
+		{"not-hex!", false},
+		{"", false},
+	}
+	for _, tt := range tests {
+		if got := looksLikeCommitSHA(tt.ref); got != tt.want {
+			t.Errorf("looksLikeCommitSHA(%q) = %v, want %v", tt.ref, got, tt.want)
+		}
+	}
+}
+
+func TestGitFetcher_Fetch_CloneFailureErrors(t *testing.T) {
+	runner := &fakeCommandRunner{err: errors.New("repository not found")}
+	fetcher := NewGitFetcherWithRunner(runner)
+
+	if _, err := fetcher.Fetch(context.Background(), "git://github.com/org/repo//policy/prod.yaml@main"); err == nil {
+		t.Error("expected an error when clone fails")
+	}
+}
+
+// fakeS3Client is a stub s3API for testing S3Fetcher without AWS credentials.
+type fakeS3Client struct {
+	body string
+	err  error
+}
+
+func (f *fakeS3Client) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &s3.GetObjectOutput{Body: io.NopCloser(bytes.NewBufferString(f.body))}, nil
+}
+
+func TestS3Fetcher_Fetch_ReadsObjectBody(t *testing.T) {
+	fetcher := NewS3FetcherWithClient(&fakeS3Client{body: "version: \"1\"\n"})
+
+	content, err := fetcher.Fetch(context.Background(), "s3://my-bucket/policies/prod.yaml")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != "version: \"1\"\n" {
+		t.Errorf("content = %q", content)
+	}
+}
+
+func TestS3Fetcher_Fetch_GetObjectErrorPropagates(t *testing.T) {
+	fetcher := NewS3FetcherWithClient(&fakeS3Client{err: errors.New("access denied")})
+
+	if _, err := fetcher.Fetch(context.Background(), "s3://my-bucket/policies/prod.yaml"); err == nil {
+		t.Error("expected an error when GetObject fails")
+	}
+}
+
+// fakeSSMClient is a stub ssmAPI for testing SSMFetcher without AWS credentials.
+type fakeSSMClient struct {
+	value string
+	err   error
+	name  string
+}
+
+func (f *fakeSSMClient) GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.name = aws.ToString(params.Name)
+	return &ssm.GetParameterOutput{Parameter: &ssmtypes.Parameter{Value: aws.String(f.value)}}, nil
+}
+
+func TestSSMFetcher_Fetch_ReadsParameterValue(t *testing.T) {
+	client := &fakeSSMClient{value: "version: \"1\"\n"}
+	fetcher := NewSSMFetcherWithClient(client)
+
+	content, err := fetcher.Fetch(context.Background(), "ssm:///sentinel/policy/prod")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(content) != "version: \"1\"\n" {
+		t.Errorf("content = %q", content)
+	}
+	if client.name != "/sentinel/policy/prod" {
+		t.Errorf("GetParameter called with name = %q, want /sentinel/policy/prod", client.name)
+	}
+}
+
+func TestSSMFetcher_Fetch_GetParameterErrorPropagates(t *testing.T) {
+	fetcher := NewSSMFetcherWithClient(&fakeSSMClient{err: errors.New("parameter not found")})
+
+	if _, err := fetcher.Fetch(context.Background(), "ssm:///sentinel/policy/prod"); err == nil {
+		t.Error("expected an error when GetParameter fails")
+	}
+}
@@ -0,0 +1,233 @@
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/byteness/aws-vault/v7/breakglass"
+	"github.com/byteness/aws-vault/v7/policy"
+	"gopkg.in/yaml.v3"
+)
+
+// LintRuleID is the stable identifier for a single lint check, e.g.
+// "SENTINEL001". IDs are never reused for a different check once published,
+// so --disable flags and inline suppression comments keep working across
+// releases.
+type LintRuleID string
+
+const (
+	// RuleUnreachableRule flags a policy rule shadowed by an earlier, broader rule.
+	RuleUnreachableRule LintRuleID = "SENTINEL001"
+	// RuleOverlappingApprovers flags approval rules with overlapping profiles but different approvers.
+	RuleOverlappingApprovers LintRuleID = "SENTINEL002"
+	// RuleBreakGlassNoExpiry flags break-glass rules with no explicit max_duration.
+	RuleBreakGlassNoExpiry LintRuleID = "SENTINEL003"
+	// RuleRateLimitShorterThanTTL flags a rate limit cooldown shorter than an approval auto-approve TTL.
+	RuleRateLimitShorterThanTTL LintRuleID = "SENTINEL004"
+	// RuleUnusedProfile flags a bootstrap-defined profile referenced by no policy, approval, or rate-limit rule.
+	RuleUnusedProfile LintRuleID = "SENTINEL005"
+	// RuleApproverNotInAnyGrant flags an approval approver who appears in no access policy grant.
+	RuleApproverNotInAnyGrant LintRuleID = "SENTINEL006"
+	// RuleAllowBeforeDeny flags an allow rule that precedes a deny rule for the same profiles.
+	RuleAllowBeforeDeny LintRuleID = "SENTINEL007"
+	// RuleOverlappingTimeWindows flags rules with overlapping time windows and different effects.
+	RuleOverlappingTimeWindows LintRuleID = "SENTINEL008"
+)
+
+// LintFinding is a single semantic/policy-quality issue, as distinct from the
+// schema ValidationIssues that Validate produces.
+type LintFinding struct {
+	Rule     LintRuleID    `json:"rule"`
+	Severity IssueSeverity `json:"severity"`
+	Location string        `json:"location"`
+	Message  string        `json:"message"`
+}
+
+// LintResult aggregates the lint findings for a single config document.
+type LintResult struct {
+	ConfigType ConfigType    `json:"config_type"`
+	Source     string        `json:"source"`
+	Findings   []LintFinding `json:"findings"`
+}
+
+// disableComment is the inline suppression marker, e.g.
+// "# sentinel:disable=SENTINEL003,SENTINEL004".
+const disableComment = "sentinel:disable="
+
+// InlineDisabledRules scans content for sentinel:disable=... comments and
+// returns the rule IDs they suppress for the whole document. Multiple IDs can
+// be comma-separated on one line, and the comment can appear anywhere in the
+// file since it isn't tied to a specific rule.
+func InlineDisabledRules(content []byte) map[LintRuleID]bool {
+	disabled := make(map[LintRuleID]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		idx := strings.Index(line, disableComment)
+		if idx == -1 {
+			continue
+		}
+		rest := line[idx+len(disableComment):]
+		for _, id := range strings.Split(rest, ",") {
+			id = strings.TrimSpace(id)
+			if id != "" {
+				disabled[LintRuleID(id)] = true
+			}
+		}
+	}
+	return disabled
+}
+
+// LintDocument runs the semantic/policy-quality rules applicable to a single
+// config document. disabledFlags are rule IDs suppressed via --disable; they
+// are combined with any inline sentinel:disable comments found in content.
+// Suppressed findings are dropped entirely rather than reported-but-hidden,
+// so they don't affect the command's exit status either.
+func LintDocument(configType ConfigType, content []byte, source string, disabledFlags map[LintRuleID]bool) LintResult {
+	result := LintResult{ConfigType: configType, Source: source}
+
+	disabled := make(map[LintRuleID]bool, len(disabledFlags))
+	for id := range disabledFlags {
+		disabled[id] = true
+	}
+	for id := range InlineDisabledRules(content) {
+		disabled[id] = true
+	}
+
+	var findings []LintFinding
+	switch configType {
+	case ConfigTypePolicy:
+		var p policy.Policy
+		if err := yaml.Unmarshal(content, &p); err == nil {
+			findings = lintPolicy(&p)
+		}
+	case ConfigTypeApproval:
+		var p policy.ApprovalPolicy
+		if err := yaml.Unmarshal(content, &p); err == nil {
+			findings = lintApproval(&p)
+		}
+	case ConfigTypeBreakGlass:
+		var p breakglass.BreakGlassPolicy
+		if err := yaml.Unmarshal(content, &p); err == nil {
+			findings = lintBreakGlass(&p)
+		}
+	}
+
+	for _, f := range findings {
+		if disabled[f.Rule] {
+			continue
+		}
+		result.Findings = append(result.Findings, f)
+	}
+
+	return result
+}
+
+// lintPolicy adapts policy.LintPolicy's findings to LintFindings with stable
+// rule IDs, so config lint reuses the same shadowed-rule/ambiguity analysis
+// policy already performs rather than re-deriving it.
+func lintPolicy(p *policy.Policy) []LintFinding {
+	var findings []LintFinding
+	for _, issue := range policy.LintPolicy(p) {
+		findings = append(findings, LintFinding{
+			Rule:     policyLintRuleID(issue.Type),
+			Severity: SeverityWarning,
+			Location: fmt.Sprintf("rules[%d]", issue.RuleIndex),
+			Message:  issue.Message,
+		})
+	}
+	return findings
+}
+
+func policyLintRuleID(t policy.LintIssueType) LintRuleID {
+	switch t {
+	case policy.LintUnreachableRule:
+		return RuleUnreachableRule
+	case policy.LintAllowBeforeDeny:
+		return RuleAllowBeforeDeny
+	case policy.LintOverlappingTimeWindows:
+		return RuleOverlappingTimeWindows
+	default:
+		return LintRuleID(t)
+	}
+}
+
+// lintApproval flags approval rules whose profiles overlap but whose
+// approvers differ, since it's ambiguous which approver set governs a
+// request matching both rules.
+func lintApproval(p *policy.ApprovalPolicy) []LintFinding {
+	var findings []LintFinding
+	for i, rule := range p.Rules {
+		for j := i + 1; j < len(p.Rules); j++ {
+			other := p.Rules[j]
+			if !stringSetsOverlap(rule.Profiles, other.Profiles) {
+				continue
+			}
+			if stringSetsEqual(rule.Approvers, other.Approvers) {
+				continue
+			}
+			findings = append(findings, LintFinding{
+				Rule:     RuleOverlappingApprovers,
+				Severity: SeverityWarning,
+				Location: fmt.Sprintf("rules[%d]", j),
+				Message: fmt.Sprintf("rule '%s' and rule '%s' apply to overlapping profiles but list different approvers",
+					rule.Name, other.Name),
+			})
+		}
+	}
+	return findings
+}
+
+// lintBreakGlass flags break-glass rules with no explicit max_duration - they
+// fall back to the package-wide default rather than an expiry the operator
+// chose deliberately.
+func lintBreakGlass(p *breakglass.BreakGlassPolicy) []LintFinding {
+	var findings []LintFinding
+	for i, rule := range p.Rules {
+		if rule.MaxDuration == 0 {
+			findings = append(findings, LintFinding{
+				Rule:     RuleBreakGlassNoExpiry,
+				Severity: SeverityWarning,
+				Location: fmt.Sprintf("rules[%d]", i),
+				Message: fmt.Sprintf("break-glass rule '%s' has no max_duration - falls back to the %v system default",
+					rule.Name, breakglass.MaxDuration),
+			})
+		}
+	}
+	return findings
+}
+
+// stringSetsOverlap returns true if a and b share any element. An empty set
+// is a wildcard and overlaps with anything, matching policy.profilesOverlap's
+// semantics for conditions lists.
+func stringSetsOverlap(a, b []string) bool {
+	if len(a) == 0 || len(b) == 0 {
+		return true
+	}
+	for _, x := range a {
+		for _, y := range b {
+			if x == y {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// stringSetsEqual returns true if a and b contain the same elements, ignoring order.
+func stringSetsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	seen := make(map[string]int, len(a))
+	for _, x := range a {
+		seen[x]++
+	}
+	for _, y := range b {
+		seen[y]--
+	}
+	for _, count := range seen {
+		if count != 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,167 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/byteness/aws-vault/v7/bootstrap"
+	"github.com/byteness/aws-vault/v7/breakglass"
+	"github.com/byteness/aws-vault/v7/policy"
+)
+
+// CrossDocumentInputs bundles the parsed documents LintCrossDocument compares.
+// Any field may be nil; each rule only runs when the documents it needs are
+// present, since most `config lint` invocations only have one document type
+// at hand.
+type CrossDocumentInputs struct {
+	Policy    *policy.Policy
+	Approval  *policy.ApprovalPolicy
+	RateLimit *breakglass.RateLimitPolicy
+	Bootstrap *bootstrap.BootstrapConfig
+}
+
+// LintCrossDocument runs lint rules that need more than one config document -
+// e.g. comparing a rate limit cooldown against an approval auto-approve TTL.
+func LintCrossDocument(in CrossDocumentInputs, disabled map[LintRuleID]bool) []LintFinding {
+	var findings []LintFinding
+
+	if in.RateLimit != nil && in.Approval != nil {
+		findings = append(findings, lintRateLimitShorterThanApprovalTTL(in.RateLimit, in.Approval)...)
+	}
+	if in.Bootstrap != nil {
+		findings = append(findings, lintUnusedProfiles(in.Bootstrap, in.Policy, in.Approval, in.RateLimit)...)
+	}
+	if in.Policy != nil && in.Approval != nil {
+		findings = append(findings, lintApproversNotInAnyGrant(in.Policy, in.Approval)...)
+	}
+
+	var kept []LintFinding
+	for _, f := range findings {
+		if !disabled[f.Rule] {
+			kept = append(kept, f)
+		}
+	}
+	return kept
+}
+
+// lintRateLimitShorterThanApprovalTTL flags a rate limit cooldown that is
+// shorter than an overlapping approval rule's auto-approve max_duration - a
+// user could re-request and get a fresh auto-approved grant before their
+// previous one even expires, defeating the rate limit's purpose.
+func lintRateLimitShorterThanApprovalTTL(rl *breakglass.RateLimitPolicy, ap *policy.ApprovalPolicy) []LintFinding {
+	var findings []LintFinding
+	for i, rlRule := range rl.Rules {
+		if rlRule.Cooldown == 0 {
+			continue
+		}
+		for _, apRule := range ap.Rules {
+			if apRule.AutoApprove == nil || apRule.AutoApprove.MaxDuration == 0 {
+				continue
+			}
+			if !stringSetsOverlap(rlRule.Profiles, apRule.Profiles) {
+				continue
+			}
+			if rlRule.Cooldown < apRule.AutoApprove.MaxDuration {
+				findings = append(findings, LintFinding{
+					Rule:     RuleRateLimitShorterThanTTL,
+					Severity: SeverityWarning,
+					Location: fmt.Sprintf("rules[%d].cooldown", i),
+					Message: fmt.Sprintf("rate limit rule '%s' cooldown (%v) is shorter than approval rule '%s' auto-approve max_duration (%v)",
+						rlRule.Name, rlRule.Cooldown, apRule.Name, apRule.AutoApprove.MaxDuration),
+				})
+			}
+		}
+	}
+	return findings
+}
+
+// lintUnusedProfiles flags bootstrap-defined profiles that no policy,
+// approval, or rate limit rule references. A rule with an empty profiles list
+// is a wildcard matching every profile, so its presence in any document
+// clears every profile as "used".
+func lintUnusedProfiles(bs *bootstrap.BootstrapConfig, pol *policy.Policy, ap *policy.ApprovalPolicy, rl *breakglass.RateLimitPolicy) []LintFinding {
+	referenced := make(map[string]bool)
+	wildcard := false
+
+	collect := func(profiles []string) {
+		if len(profiles) == 0 {
+			wildcard = true
+			return
+		}
+		for _, p := range profiles {
+			referenced[p] = true
+		}
+	}
+
+	if pol != nil {
+		for _, r := range pol.Rules {
+			collect(r.Conditions.Profiles)
+		}
+	}
+	if ap != nil {
+		for _, r := range ap.Rules {
+			collect(r.Profiles)
+		}
+	}
+	if rl != nil {
+		for _, r := range rl.Rules {
+			collect(r.Profiles)
+		}
+	}
+
+	if wildcard {
+		return nil
+	}
+
+	var findings []LintFinding
+	for i, profile := range bs.Profiles {
+		if referenced[profile.Name] {
+			continue
+		}
+		findings = append(findings, LintFinding{
+			Rule:     RuleUnusedProfile,
+			Severity: SeverityWarning,
+			Location: fmt.Sprintf("profiles[%d]", i),
+			Message:  fmt.Sprintf("profile '%s' is defined in bootstrap config but referenced by no policy, approval, or rate limit rule", profile.Name),
+		})
+	}
+	return findings
+}
+
+// lintApproversNotInAnyGrant flags approval approvers who don't appear as a
+// granted user in any access policy rule - they can approve a request for
+// access they can't be granted themselves, usually a sign the approver list
+// is stale.
+func lintApproversNotInAnyGrant(pol *policy.Policy, ap *policy.ApprovalPolicy) []LintFinding {
+	grantedUsers := make(map[string]bool)
+	wildcard := false
+	for _, r := range pol.Rules {
+		if len(r.Conditions.Users) == 0 {
+			wildcard = true
+			continue
+		}
+		for _, u := range r.Conditions.Users {
+			grantedUsers[u] = true
+		}
+	}
+	if wildcard {
+		return nil
+	}
+
+	var findings []LintFinding
+	flagged := make(map[string]bool)
+	for i, rule := range ap.Rules {
+		for _, approver := range rule.Approvers {
+			if grantedUsers[approver] || flagged[approver] {
+				continue
+			}
+			flagged[approver] = true
+			findings = append(findings, LintFinding{
+				Rule:     RuleApproverNotInAnyGrant,
+				Severity: SeverityWarning,
+				Location: fmt.Sprintf("rules[%d].approvers", i),
+				Message:  fmt.Sprintf("approver '%s' does not appear as a granted user in any access policy rule", approver),
+			})
+		}
+	}
+	return findings
+}
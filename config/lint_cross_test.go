@@ -0,0 +1,182 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	"github.com/byteness/aws-vault/v7/bootstrap"
+	"github.com/byteness/aws-vault/v7/breakglass"
+	"github.com/byteness/aws-vault/v7/policy"
+)
+
+func TestLintCrossDocument_RateLimitShorterThanApprovalTTL(t *testing.T) {
+	rl := &breakglass.RateLimitPolicy{
+		Rules: []breakglass.RateLimitRule{
+			{Name: "prod-cooldown", Profiles: []string{"prod"}, Cooldown: 5 * time.Minute},
+		},
+	}
+	ap := &policy.ApprovalPolicy{
+		Rules: []policy.ApprovalRule{
+			{
+				Name:     "prod-auto-approve",
+				Profiles: []string{"prod"},
+				AutoApprove: &policy.AutoApproveCondition{
+					MaxDuration: time.Hour,
+				},
+			},
+		},
+	}
+
+	findings := LintCrossDocument(CrossDocumentInputs{RateLimit: rl, Approval: ap}, nil)
+
+	found := false
+	for _, f := range findings {
+		if f.Rule == RuleRateLimitShorterThanTTL {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected SENTINEL004 rate-limit-shorter-than-ttl finding, got %v", findings)
+	}
+}
+
+func TestLintCrossDocument_RateLimitLongerThanApprovalTTLNotFlagged(t *testing.T) {
+	rl := &breakglass.RateLimitPolicy{
+		Rules: []breakglass.RateLimitRule{
+			{Name: "prod-cooldown", Profiles: []string{"prod"}, Cooldown: 2 * time.Hour},
+		},
+	}
+	ap := &policy.ApprovalPolicy{
+		Rules: []policy.ApprovalRule{
+			{
+				Name:     "prod-auto-approve",
+				Profiles: []string{"prod"},
+				AutoApprove: &policy.AutoApproveCondition{
+					MaxDuration: time.Hour,
+				},
+			},
+		},
+	}
+
+	findings := LintCrossDocument(CrossDocumentInputs{RateLimit: rl, Approval: ap}, nil)
+
+	for _, f := range findings {
+		if f.Rule == RuleRateLimitShorterThanTTL {
+			t.Errorf("expected no finding when cooldown exceeds TTL, got %v", findings)
+		}
+	}
+}
+
+func TestLintCrossDocument_UnusedProfile(t *testing.T) {
+	bs := &bootstrap.BootstrapConfig{
+		Profiles: []bootstrap.ProfileConfig{
+			{Name: "dev"},
+			{Name: "orphaned"},
+		},
+	}
+	pol := &policy.Policy{
+		Rules: []policy.Rule{
+			{Name: "allow-dev", Conditions: policy.Condition{Profiles: []string{"dev"}}},
+		},
+	}
+
+	findings := LintCrossDocument(CrossDocumentInputs{Bootstrap: bs, Policy: pol}, nil)
+
+	found := false
+	for _, f := range findings {
+		if f.Rule == RuleUnusedProfile {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected SENTINEL005 unused-profile finding for 'orphaned', got %v", findings)
+	}
+}
+
+func TestLintCrossDocument_WildcardProfileClearsUnused(t *testing.T) {
+	bs := &bootstrap.BootstrapConfig{
+		Profiles: []bootstrap.ProfileConfig{
+			{Name: "dev"},
+			{Name: "prod"},
+		},
+	}
+	pol := &policy.Policy{
+		Rules: []policy.Rule{
+			{Name: "allow-all"},
+		},
+	}
+
+	findings := LintCrossDocument(CrossDocumentInputs{Bootstrap: bs, Policy: pol}, nil)
+
+	for _, f := range findings {
+		if f.Rule == RuleUnusedProfile {
+			t.Errorf("expected wildcard policy rule to clear all profiles as used, got %v", findings)
+		}
+	}
+}
+
+func TestLintCrossDocument_ApproverNotInAnyGrant(t *testing.T) {
+	pol := &policy.Policy{
+		Rules: []policy.Rule{
+			{Name: "allow-dev", Conditions: policy.Condition{Users: []string{"alice"}}},
+		},
+	}
+	ap := &policy.ApprovalPolicy{
+		Rules: []policy.ApprovalRule{
+			{Name: "dev-approval", Approvers: []string{"bob"}},
+		},
+	}
+
+	findings := LintCrossDocument(CrossDocumentInputs{Policy: pol, Approval: ap}, nil)
+
+	found := false
+	for _, f := range findings {
+		if f.Rule == RuleApproverNotInAnyGrant {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected SENTINEL006 approver-not-in-any-grant finding for 'bob', got %v", findings)
+	}
+}
+
+func TestLintCrossDocument_ApproverWithGrantNotFlagged(t *testing.T) {
+	pol := &policy.Policy{
+		Rules: []policy.Rule{
+			{Name: "allow-dev", Conditions: policy.Condition{Users: []string{"alice"}}},
+		},
+	}
+	ap := &policy.ApprovalPolicy{
+		Rules: []policy.ApprovalRule{
+			{Name: "dev-approval", Approvers: []string{"alice"}},
+		},
+	}
+
+	findings := LintCrossDocument(CrossDocumentInputs{Policy: pol, Approval: ap}, nil)
+
+	for _, f := range findings {
+		if f.Rule == RuleApproverNotInAnyGrant {
+			t.Errorf("expected no finding when approver also appears as a granted user, got %v", findings)
+		}
+	}
+}
+
+func TestLintCrossDocument_RespectsDisabledRules(t *testing.T) {
+	bs := &bootstrap.BootstrapConfig{
+		Profiles: []bootstrap.ProfileConfig{{Name: "orphaned"}},
+	}
+	pol := &policy.Policy{
+		Rules: []policy.Rule{
+			{Name: "allow-dev", Conditions: policy.Condition{Profiles: []string{"dev"}}},
+		},
+	}
+
+	disabled := map[LintRuleID]bool{RuleUnusedProfile: true}
+	findings := LintCrossDocument(CrossDocumentInputs{Bootstrap: bs, Policy: pol}, disabled)
+
+	for _, f := range findings {
+		if f.Rule == RuleUnusedProfile {
+			t.Errorf("expected SENTINEL005 to be suppressed, got %v", findings)
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package config
+
+import (
+	"testing"
+)
+
+func TestLintDocument_Policy_UnreachableRule(t *testing.T) {
+	content := []byte(`
+version: "1"
+rules:
+  - name: allow-all
+    effect: allow
+  - name: allow-dev
+    effect: allow
+    conditions:
+      profiles:
+        - dev
+`)
+
+	result := LintDocument(ConfigTypePolicy, content, "policy.yaml", nil)
+
+	found := false
+	for _, f := range result.Findings {
+		if f.Rule == RuleUnreachableRule {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected SENTINEL001 unreachable-rule finding, got %v", result.Findings)
+	}
+}
+
+func TestLintDocument_Approval_OverlappingApprovers(t *testing.T) {
+	content := []byte(`
+version: "1"
+rules:
+  - name: team-a
+    profiles:
+      - prod
+    approvers:
+      - alice
+  - name: team-b
+    profiles:
+      - prod
+    approvers:
+      - bob
+`)
+
+	result := LintDocument(ConfigTypeApproval, content, "approval.yaml", nil)
+
+	found := false
+	for _, f := range result.Findings {
+		if f.Rule == RuleOverlappingApprovers {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected SENTINEL002 overlapping-approvers finding, got %v", result.Findings)
+	}
+}
+
+func TestLintDocument_Approval_SameApproversNotFlagged(t *testing.T) {
+	content := []byte(`
+version: "1"
+rules:
+  - name: team-a
+    profiles:
+      - prod
+    approvers:
+      - alice
+  - name: team-a-again
+    profiles:
+      - prod
+    approvers:
+      - alice
+`)
+
+	result := LintDocument(ConfigTypeApproval, content, "approval.yaml", nil)
+
+	for _, f := range result.Findings {
+		if f.Rule == RuleOverlappingApprovers {
+			t.Errorf("expected no overlapping-approvers finding when approver sets match, got %v", result.Findings)
+		}
+	}
+}
+
+func TestLintDocument_BreakGlass_NoExpiry(t *testing.T) {
+	content := []byte(`
+version: "1"
+rules:
+  - name: incident-response
+    users:
+      - oncall
+    allowed_reason_codes:
+      - incident
+`)
+
+	result := LintDocument(ConfigTypeBreakGlass, content, "breakglass.yaml", nil)
+
+	found := false
+	for _, f := range result.Findings {
+		if f.Rule == RuleBreakGlassNoExpiry {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("expected SENTINEL003 break-glass-no-expiry finding, got %v", result.Findings)
+	}
+}
+
+func TestLintDocument_BreakGlass_ExplicitExpiryNotFlagged(t *testing.T) {
+	content := []byte(`
+version: "1"
+rules:
+  - name: incident-response
+    users:
+      - oncall
+    allowed_reason_codes:
+      - incident
+    max_duration: 30m
+`)
+
+	result := LintDocument(ConfigTypeBreakGlass, content, "breakglass.yaml", nil)
+
+	for _, f := range result.Findings {
+		if f.Rule == RuleBreakGlassNoExpiry {
+			t.Errorf("expected no finding when max_duration is set, got %v", result.Findings)
+		}
+	}
+}
+
+func TestLintDocument_DisabledViaFlag(t *testing.T) {
+	content := []byte(`
+version: "1"
+rules:
+  - name: allow-all
+    effect: allow
+  - name: allow-dev
+    effect: allow
+    conditions:
+      profiles:
+        - dev
+`)
+
+	disabled := map[LintRuleID]bool{RuleUnreachableRule: true}
+	result := LintDocument(ConfigTypePolicy, content, "policy.yaml", disabled)
+
+	for _, f := range result.Findings {
+		if f.Rule == RuleUnreachableRule {
+			t.Errorf("expected SENTINEL001 to be suppressed, got %v", result.Findings)
+		}
+	}
+}
+
+func TestLintDocument_DisabledViaInlineComment(t *testing.T) {
+	content := []byte(`
+# sentinel:disable=SENTINEL001
+version: "1"
+rules:
+  - name: allow-all
+    effect: allow
+  - name: allow-dev
+    effect: allow
+    conditions:
+      profiles:
+        - dev
+`)
+
+	result := LintDocument(ConfigTypePolicy, content, "policy.yaml", nil)
+
+	for _, f := range result.Findings {
+		if f.Rule == RuleUnreachableRule {
+			t.Errorf("expected SENTINEL001 to be suppressed via inline comment, got %v", result.Findings)
+		}
+	}
+}
+
+func TestInlineDisabledRules_MultipleIDs(t *testing.T) {
+	content := []byte("# sentinel:disable=SENTINEL001,SENTINEL002\nversion: \"1\"\n")
+
+	disabled := InlineDisabledRules(content)
+	if !disabled[RuleUnreachableRule] || !disabled[RuleOverlappingApprovers] {
+		t.Errorf("expected both rule IDs suppressed, got %v", disabled)
+	}
+}
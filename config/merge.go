@@ -0,0 +1,181 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// overrideTag is the YAML tag that marks a sequence as replacing rather than
+// concatenating with the corresponding sequence already merged.
+const overrideTag = "!override"
+
+// MergeConflict describes a scalar key whose value differs between the
+// documents being merged. Conflicts are reported rather than silently
+// resolved by taking the last value, since a silent conflict usually means a
+// team fragment accidentally clobbered a value another fragment depended on.
+type MergeConflict struct {
+	Path   string `json:"path"`   // dotted key path, e.g. "defaults.region"
+	Source string `json:"source"` // file the conflicting value came from
+	Line   int    `json:"line"`
+}
+
+// ResolveIncludes returns the fragment files that should be merged into
+// primaryPath, in merge order. If the primary document has an explicit
+// `include:` list, those paths are used (resolved relative to primaryPath's
+// directory). Otherwise every *.yaml/*.yml file in a sibling conf.d directory
+// is included, sorted by filename for a deterministic merge order. It returns
+// a nil slice, not an error, when neither an include list nor a conf.d
+// directory exists - plain single-file configs are the common case.
+func ResolveIncludes(primaryPath string) ([]string, error) {
+	dir := filepath.Dir(primaryPath)
+
+	content, err := os.ReadFile(primaryPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var doc struct {
+		Include []string `yaml:"include"`
+	}
+	if err := yaml.Unmarshal(content, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", primaryPath, err)
+	}
+
+	if len(doc.Include) > 0 {
+		paths := make([]string, len(doc.Include))
+		for i, p := range doc.Include {
+			if filepath.IsAbs(p) {
+				paths[i] = p
+			} else {
+				paths[i] = filepath.Join(dir, p)
+			}
+		}
+		return paths, nil
+	}
+
+	confDir := filepath.Join(dir, "conf.d")
+	entries, err := os.ReadDir(confDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var fragments []string
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		switch strings.ToLower(filepath.Ext(e.Name())) {
+		case ".yaml", ".yml":
+			fragments = append(fragments, filepath.Join(confDir, e.Name()))
+		}
+	}
+	sort.Strings(fragments)
+
+	return fragments, nil
+}
+
+// MergeFragments merges primaryPath and fragmentPaths (in order) into a
+// single effective YAML document. Maps are merged key-by-key, lists are
+// concatenated by default, and a fragment can tag a list with `!override` to
+// replace the accumulated list instead of appending to it. Conflicting scalar
+// values are recorded as MergeConflicts rather than silently overwritten, so
+// callers can surface them as validation errors.
+func MergeFragments(primaryPath string, fragmentPaths []string) ([]byte, []MergeConflict, error) {
+	primaryContent, err := os.ReadFile(primaryPath)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var merged yaml.Node
+	if err := yaml.Unmarshal(primaryContent, &merged); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse %s: %w", primaryPath, err)
+	}
+
+	var conflicts []MergeConflict
+	for _, fragPath := range fragmentPaths {
+		fragContent, err := os.ReadFile(fragPath)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var fragDoc yaml.Node
+		if err := yaml.Unmarshal(fragContent, &fragDoc); err != nil {
+			return nil, nil, fmt.Errorf("failed to parse %s: %w", fragPath, err)
+		}
+		if len(fragDoc.Content) == 0 {
+			continue // empty fragment
+		}
+
+		if len(merged.Content) == 0 {
+			merged = fragDoc
+			continue
+		}
+
+		mergeNodes(merged.Content[0], fragDoc.Content[0], "", fragPath, &conflicts)
+	}
+
+	out, err := yaml.Marshal(&merged)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal merged config: %w", err)
+	}
+
+	return out, conflicts, nil
+}
+
+// mergeNodes merges src into dst in place, recording scalar conflicts at path.
+func mergeNodes(dst, src *yaml.Node, path, source string, conflicts *[]MergeConflict) {
+	if dst.Kind == yaml.MappingNode && src.Kind == yaml.MappingNode {
+		mergeMappingNodes(dst, src, path, source, conflicts)
+		return
+	}
+
+	if dst.Kind == yaml.SequenceNode && src.Kind == yaml.SequenceNode {
+		if src.Tag == overrideTag {
+			dst.Content = src.Content
+			return
+		}
+		dst.Content = append(dst.Content, src.Content...)
+		return
+	}
+
+	if dst.Value != src.Value || dst.Kind != src.Kind {
+		*conflicts = append(*conflicts, MergeConflict{Path: path, Source: source, Line: src.Line})
+	}
+}
+
+// mergeMappingNodes merges the key/value pairs of src into dst, recursing into
+// shared keys and appending keys dst doesn't already have.
+func mergeMappingNodes(dst, src *yaml.Node, path, source string, conflicts *[]MergeConflict) {
+	for i := 0; i+1 < len(src.Content); i += 2 {
+		key := src.Content[i]
+		value := src.Content[i+1]
+
+		childPath := key.Value
+		if path != "" {
+			childPath = path + "." + key.Value
+		}
+
+		existing := -1
+		for j := 0; j+1 < len(dst.Content); j += 2 {
+			if dst.Content[j].Value == key.Value {
+				existing = j
+				break
+			}
+		}
+
+		if existing == -1 {
+			dst.Content = append(dst.Content, key, value)
+			continue
+		}
+
+		mergeNodes(dst.Content[existing+1], value, childPath, source, conflicts)
+	}
+}
@@ -0,0 +1,182 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+func writeTestFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		t.Fatalf("failed to create dir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write file: %v", err)
+	}
+}
+
+func TestResolveIncludes_ExplicitList(t *testing.T) {
+	dir := t.TempDir()
+	primary := filepath.Join(dir, "policy.yaml")
+	writeTestFile(t, primary, "include:\n  - extra.yaml\n  - nested/more.yaml\n")
+	writeTestFile(t, filepath.Join(dir, "extra.yaml"), "rules: []\n")
+	writeTestFile(t, filepath.Join(dir, "nested/more.yaml"), "rules: []\n")
+
+	paths, err := ResolveIncludes(primary)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "extra.yaml"), filepath.Join(dir, "nested/more.yaml")}
+	if len(paths) != len(want) {
+		t.Fatalf("expected %v, got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("expected %s at index %d, got %s", want[i], i, paths[i])
+		}
+	}
+}
+
+func TestResolveIncludes_ConfDDirectory(t *testing.T) {
+	dir := t.TempDir()
+	primary := filepath.Join(dir, "policy.yaml")
+	writeTestFile(t, primary, "version: \"1\"\nrules: []\n")
+	writeTestFile(t, filepath.Join(dir, "conf.d/b.yaml"), "rules: []\n")
+	writeTestFile(t, filepath.Join(dir, "conf.d/a.yml"), "rules: []\n")
+	writeTestFile(t, filepath.Join(dir, "conf.d/ignore.txt"), "not yaml\n")
+
+	paths, err := ResolveIncludes(primary)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := []string{filepath.Join(dir, "conf.d/a.yml"), filepath.Join(dir, "conf.d/b.yaml")}
+	if len(paths) != len(want) {
+		t.Fatalf("expected %v, got %v", want, paths)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("expected %s at index %d, got %s", want[i], i, paths[i])
+		}
+	}
+}
+
+func TestResolveIncludes_NoOverlayReturnsNil(t *testing.T) {
+	dir := t.TempDir()
+	primary := filepath.Join(dir, "policy.yaml")
+	writeTestFile(t, primary, "version: \"1\"\nrules: []\n")
+
+	paths, err := ResolveIncludes(primary)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(paths) != 0 {
+		t.Errorf("expected no fragments, got %v", paths)
+	}
+}
+
+func TestMergeFragments_MapsMergedKeyByKey(t *testing.T) {
+	dir := t.TempDir()
+	primary := filepath.Join(dir, "policy.yaml")
+	writeTestFile(t, primary, "version: \"1\"\nrules:\n  - name: allow-dev\n    effect: allow\n")
+	frag := filepath.Join(dir, "conf.d/team-a.yaml")
+	writeTestFile(t, frag, "description: team-a overlay\n")
+
+	merged, conflicts, err := MergeFragments(primary, []string{frag})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %v", conflicts)
+	}
+
+	var doc map[string]interface{}
+	if err := yaml.Unmarshal(merged, &doc); err != nil {
+		t.Fatalf("failed to parse merged output: %v", err)
+	}
+	if doc["description"] != "team-a overlay" {
+		t.Errorf("expected merged description, got %v", doc["description"])
+	}
+	if doc["version"] != "1" {
+		t.Errorf("expected primary version preserved, got %v", doc["version"])
+	}
+}
+
+func TestMergeFragments_ListsConcatenateByDefault(t *testing.T) {
+	dir := t.TempDir()
+	primary := filepath.Join(dir, "policy.yaml")
+	writeTestFile(t, primary, "rules:\n  - name: base-rule\n    effect: allow\n")
+	frag := filepath.Join(dir, "conf.d/team-a.yaml")
+	writeTestFile(t, frag, "rules:\n  - name: team-a-rule\n    effect: deny\n")
+
+	merged, _, err := MergeFragments(primary, []string{frag})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc struct {
+		Rules []struct {
+			Name string `yaml:"name"`
+		} `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(merged, &doc); err != nil {
+		t.Fatalf("failed to parse merged output: %v", err)
+	}
+	if len(doc.Rules) != 2 {
+		t.Fatalf("expected 2 rules after concatenation, got %d", len(doc.Rules))
+	}
+	if doc.Rules[0].Name != "base-rule" || doc.Rules[1].Name != "team-a-rule" {
+		t.Errorf("expected base-rule then team-a-rule, got %v", doc.Rules)
+	}
+}
+
+func TestMergeFragments_OverrideTagReplacesList(t *testing.T) {
+	dir := t.TempDir()
+	primary := filepath.Join(dir, "policy.yaml")
+	writeTestFile(t, primary, "rules:\n  - name: base-rule\n    effect: allow\n")
+	frag := filepath.Join(dir, "conf.d/team-a.yaml")
+	writeTestFile(t, frag, "rules: !override\n  - name: replacement-rule\n    effect: deny\n")
+
+	merged, _, err := MergeFragments(primary, []string{frag})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var doc struct {
+		Rules []struct {
+			Name string `yaml:"name"`
+		} `yaml:"rules"`
+	}
+	if err := yaml.Unmarshal(merged, &doc); err != nil {
+		t.Fatalf("failed to parse merged output: %v", err)
+	}
+	if len(doc.Rules) != 1 || doc.Rules[0].Name != "replacement-rule" {
+		t.Errorf("expected override to replace list with single entry, got %v", doc.Rules)
+	}
+}
+
+func TestMergeFragments_ScalarConflictReported(t *testing.T) {
+	dir := t.TempDir()
+	primary := filepath.Join(dir, "policy.yaml")
+	writeTestFile(t, primary, "version: \"1\"\n")
+	frag := filepath.Join(dir, "conf.d/team-a.yaml")
+	writeTestFile(t, frag, "version: \"2\"\n")
+
+	_, conflicts, err := MergeFragments(primary, []string{frag})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(conflicts) != 1 {
+		t.Fatalf("expected 1 conflict, got %v", conflicts)
+	}
+	if conflicts[0].Path != "version" {
+		t.Errorf("expected conflict path 'version', got %q", conflicts[0].Path)
+	}
+	if conflicts[0].Source != frag {
+		t.Errorf("expected conflict source %q, got %q", frag, conflicts[0].Source)
+	}
+}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Spec describes a team roster and policy shape that GenerateFromSpec
+// renders into the four Sentinel configs (policy, approval, break-glass,
+// rate limit). It is the input format for `sentinel config generate --spec`.
+type Spec struct {
+	Version  string     `yaml:"version"`
+	Template TemplateID `yaml:"template"` // basic, approvals, or full - selects which built-in templates to render
+
+	Profiles       []SpecProfile       `yaml:"profiles"`
+	Groups         []SpecGroup         `yaml:"groups,omitempty"`
+	ApprovalChains []SpecApprovalChain `yaml:"approval_chains,omitempty"`
+	RateLimitTiers []SpecRateLimitTier `yaml:"rate_limit_tiers,omitempty"`
+}
+
+// SpecProfile is a single AWS profile and the users/groups that need access
+// to it, along with which approval chain and rate-limit tier govern it.
+type SpecProfile struct {
+	Name          string   `yaml:"name"`
+	Users         []string `yaml:"users,omitempty"`
+	Groups        []string `yaml:"groups,omitempty"`
+	ApprovalChain string   `yaml:"approval_chain,omitempty"`
+	RateLimitTier string   `yaml:"rate_limit_tier,omitempty"`
+}
+
+// SpecGroup is a named set of users that profiles can reference instead of
+// repeating the same usernames across every profile.
+type SpecGroup struct {
+	Name    string   `yaml:"name"`
+	Members []string `yaml:"members"`
+}
+
+// SpecApprovalChain is a reusable set of approvers and auto-approve rules
+// that one or more profiles can share.
+type SpecApprovalChain struct {
+	Name                     string   `yaml:"name"`
+	Approvers                []string `yaml:"approvers"`
+	BusinessHoursAutoApprove bool     `yaml:"business_hours_auto_approve,omitempty"`
+	AutoApproveUsers         []string `yaml:"auto_approve_users,omitempty"`
+	MaxAutoApproveDuration   string   `yaml:"max_auto_approve_duration,omitempty"` // e.g. "1h"
+}
+
+// SpecRateLimitTier is a reusable set of break-glass rate limits that one or
+// more profiles can share.
+type SpecRateLimitTier struct {
+	Name          string `yaml:"name"`
+	Cooldown      string `yaml:"cooldown"` // e.g. "30m"
+	MaxPerUser    int    `yaml:"max_per_user"`
+	MaxPerProfile int    `yaml:"max_per_profile"`
+	QuotaWindow   string `yaml:"quota_window"` // e.g. "24h"
+}
+
+// LoadSpec reads and parses a spec file from path.
+func LoadSpec(path string) (*Spec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec file: %w", err)
+	}
+
+	var spec Spec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec file: %w", err)
+	}
+
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	return &spec, nil
+}
+
+// Validate checks that the spec is well-formed: it has a known template,
+// at least one profile, every profile's approval chain / rate-limit tier
+// reference resolves to a chain / tier defined in the spec, and - for the
+// approvals/full templates, which render an approval rule per profile -
+// every profile resolves to at least one approver.
+func (s *Spec) Validate() error {
+	if !s.Template.IsValid() {
+		return fmt.Errorf("invalid spec template: %s", s.Template)
+	}
+	if len(s.Profiles) == 0 {
+		return fmt.Errorf("spec must define at least one profile")
+	}
+
+	chains := make(map[string]SpecApprovalChain, len(s.ApprovalChains))
+	for _, c := range s.ApprovalChains {
+		chains[c.Name] = c
+	}
+	tiers := make(map[string]bool, len(s.RateLimitTiers))
+	for _, t := range s.RateLimitTiers {
+		tiers[t.Name] = true
+	}
+
+	requiresApprovers := s.Template == TemplateApprovals || s.Template == TemplateFull
+
+	for _, p := range s.Profiles {
+		if p.Name == "" {
+			return fmt.Errorf("spec profile is missing a name")
+		}
+		chain, chainExists := chains[p.ApprovalChain]
+		if p.ApprovalChain != "" && !chainExists {
+			return fmt.Errorf("profile %s references unknown approval_chain %q", p.Name, p.ApprovalChain)
+		}
+		if p.RateLimitTier != "" && !tiers[p.RateLimitTier] {
+			return fmt.Errorf("profile %s references unknown rate_limit_tier %q", p.Name, p.RateLimitTier)
+		}
+		if requiresApprovers && len(chain.Approvers) == 0 {
+			return fmt.Errorf("profile %s must reference an approval_chain with at least one approver for the %s template", p.Name, s.Template)
+		}
+	}
+
+	return nil
+}
+
+// ResolveUsers returns the set of usernames with access to a profile: its
+// directly listed users plus the members of any groups it references.
+func (s *Spec) ResolveUsers(p SpecProfile) []string {
+	groupMembers := make(map[string][]string, len(s.Groups))
+	for _, g := range s.Groups {
+		groupMembers[g.Name] = g.Members
+	}
+
+	seen := make(map[string]bool)
+	var users []string
+	add := func(u string) {
+		if !seen[u] {
+			seen[u] = true
+			users = append(users, u)
+		}
+	}
+
+	for _, u := range p.Users {
+		add(u)
+	}
+	for _, g := range p.Groups {
+		for _, u := range groupMembers[g] {
+			add(u)
+		}
+	}
+
+	return users
+}
+
+// ApprovalChain returns the named approval chain, or false if undefined.
+func (s *Spec) ApprovalChain(name string) (SpecApprovalChain, bool) {
+	for _, c := range s.ApprovalChains {
+		if c.Name == name {
+			return c, true
+		}
+	}
+	return SpecApprovalChain{}, false
+}
+
+// RateLimitTier returns the named rate-limit tier, or false if undefined.
+func (s *Spec) RateLimitTier(name string) (SpecRateLimitTier, bool) {
+	for _, t := range s.RateLimitTiers {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return SpecRateLimitTier{}, false
+}
+
+// ParseSpecDuration parses a Go duration string, returning zero for an empty
+// string rather than an error, since most spec duration fields are optional.
+func ParseSpecDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,158 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestLoadSpec_ValidBasicSpec(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "spec.yaml")
+	content := `
+version: "1"
+template: basic
+profiles:
+  - name: dev
+    users:
+      - alice
+`
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write spec: %v", err)
+	}
+
+	spec, err := LoadSpec(path)
+	if err != nil {
+		t.Fatalf("LoadSpec() error = %v", err)
+	}
+	if spec.Template != TemplateBasic {
+		t.Errorf("Template = %v, want %v", spec.Template, TemplateBasic)
+	}
+	if len(spec.Profiles) != 1 || spec.Profiles[0].Name != "dev" {
+		t.Errorf("Profiles = %+v, want one profile named dev", spec.Profiles)
+	}
+}
+
+func TestLoadSpec_MissingFileReturnsError(t *testing.T) {
+	_, err := LoadSpec(filepath.Join(t.TempDir(), "missing.yaml"))
+	if err == nil {
+		t.Error("expected an error for a missing spec file")
+	}
+}
+
+func TestSpec_Validate_UnknownApprovalChainReferenceErrors(t *testing.T) {
+	spec := &Spec{
+		Template: TemplateBasic,
+		Profiles: []SpecProfile{
+			{Name: "prod", ApprovalChain: "does-not-exist"},
+		},
+	}
+
+	if err := spec.Validate(); err == nil {
+		t.Error("expected an error for an unknown approval_chain reference")
+	}
+}
+
+func TestSpec_Validate_UnknownRateLimitTierReferenceErrors(t *testing.T) {
+	spec := &Spec{
+		Template: TemplateBasic,
+		Profiles: []SpecProfile{
+			{Name: "prod", RateLimitTier: "does-not-exist"},
+		},
+	}
+
+	if err := spec.Validate(); err == nil {
+		t.Error("expected an error for an unknown rate_limit_tier reference")
+	}
+}
+
+func TestSpec_Validate_NoProfilesErrors(t *testing.T) {
+	spec := &Spec{Template: TemplateBasic}
+
+	if err := spec.Validate(); err == nil {
+		t.Error("expected an error when no profiles are defined")
+	}
+}
+
+func TestSpec_ResolveUsers_MergesDirectUsersAndGroupMembers(t *testing.T) {
+	spec := &Spec{
+		Groups: []SpecGroup{
+			{Name: "sre", Members: []string{"bob", "carol"}},
+		},
+	}
+	profile := SpecProfile{Name: "prod", Users: []string{"alice", "bob"}, Groups: []string{"sre"}}
+
+	users := spec.ResolveUsers(profile)
+
+	want := map[string]bool{"alice": true, "bob": true, "carol": true}
+	if len(users) != len(want) {
+		t.Fatalf("ResolveUsers() = %v, want 3 unique users", users)
+	}
+	for _, u := range users {
+		if !want[u] {
+			t.Errorf("unexpected user %q in resolved set", u)
+		}
+	}
+}
+
+func TestSpec_ApprovalChain_LooksUpByName(t *testing.T) {
+	spec := &Spec{ApprovalChains: []SpecApprovalChain{{Name: "tier1", Approvers: []string{"alice"}}}}
+
+	chain, ok := spec.ApprovalChain("tier1")
+	if !ok {
+		t.Fatal("expected tier1 to be found")
+	}
+	if len(chain.Approvers) != 1 || chain.Approvers[0] != "alice" {
+		t.Errorf("Approvers = %v, want [alice]", chain.Approvers)
+	}
+
+	if _, ok := spec.ApprovalChain("missing"); ok {
+		t.Error("expected missing chain lookup to return false")
+	}
+}
+
+func TestSpec_RateLimitTier_LooksUpByName(t *testing.T) {
+	spec := &Spec{RateLimitTiers: []SpecRateLimitTier{{Name: "standard", MaxPerUser: 3}}}
+
+	tier, ok := spec.RateLimitTier("standard")
+	if !ok {
+		t.Fatal("expected standard tier to be found")
+	}
+	if tier.MaxPerUser != 3 {
+		t.Errorf("MaxPerUser = %d, want 3", tier.MaxPerUser)
+	}
+
+	if _, ok := spec.RateLimitTier("missing"); ok {
+		t.Error("expected missing tier lookup to return false")
+	}
+}
+
+func TestParseSpecDuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    string
+		wantErr bool
+	}{
+		{"", "0s", false},
+		{"30m", "30m0s", false},
+		{"not-a-duration", "", true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseSpecDuration(tc.in)
+			if tc.wantErr {
+				if err == nil {
+					t.Error("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got.String() != tc.want {
+				t.Errorf("ParseSpecDuration(%q) = %v, want %v", tc.in, got, tc.want)
+			}
+		})
+	}
+}
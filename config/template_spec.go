@@ -0,0 +1,200 @@
+package config
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed templates
+var builtinTemplates embed.FS
+
+// specProfileView is the per-profile data made available to templates,
+// with approval chains and rate-limit tiers already resolved so templates
+// stay declarative.
+type specProfileView struct {
+	Name   string
+	Users  []string
+	Groups []string
+
+	Approvers                []string
+	BusinessHoursAutoApprove bool
+	AutoApproveUsers         []string
+	MaxAutoApproveDuration   string
+
+	Cooldown      string
+	MaxPerUser    int
+	MaxPerProfile int
+	QuotaWindow   string
+}
+
+// specView is the root data passed to every template when rendering from a spec.
+type specView struct {
+	Profiles []specProfileView
+}
+
+// GenerateFromSpec renders the configs described by spec using Go templates.
+// Built-in templates are used for spec.Template (basic, approvals, full)
+// unless templateDir is set, in which case templateDir/<template>/*.tmpl and
+// templateDir/partials/*.tmpl are used instead, letting orgs override or
+// extend the built-in presets without forking Sentinel.
+func GenerateFromSpec(spec *Spec, templateDir string) (*TemplateOutput, error) {
+	if spec == nil {
+		return nil, fmt.Errorf("spec is required")
+	}
+	if err := spec.Validate(); err != nil {
+		return nil, err
+	}
+
+	tmpl, err := loadSpecTemplates(spec.Template, templateDir)
+	if err != nil {
+		return nil, err
+	}
+
+	view := buildSpecView(spec)
+
+	output := &TemplateOutput{}
+	targets := []struct {
+		name string
+		dest *string
+	}{
+		{"policy.yaml.tmpl", &output.Policy},
+		{"approval.yaml.tmpl", &output.Approval},
+		{"breakglass.yaml.tmpl", &output.BreakGlass},
+		{"ratelimit.yaml.tmpl", &output.RateLimit},
+	}
+
+	for _, target := range targets {
+		t := tmpl.Lookup(target.name)
+		if t == nil {
+			continue // this preset doesn't render this document
+		}
+		var buf bytes.Buffer
+		if err := t.Execute(&buf, view); err != nil {
+			return nil, fmt.Errorf("failed to render %s: %w", target.name, err)
+		}
+		*target.dest = buf.String()
+	}
+
+	return output, nil
+}
+
+// buildSpecView resolves group membership and shared approval chains / rate
+// limit tiers into a flat, per-profile view that templates can iterate over
+// without needing to look anything up themselves.
+func buildSpecView(spec *Spec) specView {
+	view := specView{}
+
+	for _, p := range spec.Profiles {
+		pv := specProfileView{
+			Name:   p.Name,
+			Users:  spec.ResolveUsers(p),
+			Groups: p.Groups,
+		}
+
+		if p.ApprovalChain != "" {
+			if chain, ok := spec.ApprovalChain(p.ApprovalChain); ok {
+				pv.Approvers = chain.Approvers
+				pv.BusinessHoursAutoApprove = chain.BusinessHoursAutoApprove
+				pv.AutoApproveUsers = chain.AutoApproveUsers
+				pv.MaxAutoApproveDuration = chain.MaxAutoApproveDuration
+			}
+		}
+
+		if p.RateLimitTier != "" {
+			if tier, ok := spec.RateLimitTier(p.RateLimitTier); ok {
+				pv.Cooldown = tier.Cooldown
+				pv.MaxPerUser = tier.MaxPerUser
+				pv.MaxPerProfile = tier.MaxPerProfile
+				pv.QuotaWindow = tier.QuotaWindow
+			}
+		}
+
+		view.Profiles = append(view.Profiles, pv)
+	}
+
+	return view
+}
+
+// loadSpecTemplates parses the template set for preset, from templateDir if
+// set, or from the built-in embedded templates otherwise.
+func loadSpecTemplates(preset TemplateID, templateDir string) (*template.Template, error) {
+	if templateDir != "" {
+		return loadSpecTemplatesFromDir(preset, templateDir)
+	}
+
+	sub, err := fs.Sub(builtinTemplates, "templates")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load built-in templates: %w", err)
+	}
+
+	root := template.New(string(preset)).Funcs(templateFuncMap())
+	root, err = root.ParseFS(sub, string(preset)+"/*.tmpl", "partials/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse built-in templates for %s: %w", preset, err)
+	}
+	return root, nil
+}
+
+// loadSpecTemplatesFromDir parses an org-provided override of the built-in
+// templates. partials/ is optional; the preset directory is not.
+func loadSpecTemplatesFromDir(preset TemplateID, templateDir string) (*template.Template, error) {
+	presetFiles, err := filepath.Glob(filepath.Join(templateDir, string(preset), "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to glob templates in %s: %w", templateDir, err)
+	}
+	if len(presetFiles) == 0 {
+		return nil, fmt.Errorf("no templates found for %s in %s", preset, templateDir)
+	}
+
+	root := template.New(string(preset)).Funcs(templateFuncMap())
+	root, err = root.ParseFiles(presetFiles...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse templates in %s: %w", templateDir, err)
+	}
+
+	partialFiles, err := filepath.Glob(filepath.Join(templateDir, "partials", "*.tmpl"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to glob partials in %s: %w", templateDir, err)
+	}
+	if len(partialFiles) > 0 {
+		root, err = root.ParseFiles(partialFiles...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse partials in %s: %w", templateDir, err)
+		}
+	}
+
+	return root, nil
+}
+
+// templateFuncMap provides a small set of sprig-like helpers for use in spec
+// templates: default values, list joining, and case conversion.
+func templateFuncMap() template.FuncMap {
+	return template.FuncMap{
+		"default": func(def, val interface{}) interface{} {
+			switch v := val.(type) {
+			case string:
+				if v == "" {
+					return def
+				}
+			case nil:
+				return def
+			}
+			return val
+		},
+		"join":     strings.Join,
+		"upper":    strings.ToUpper,
+		"lower":    strings.ToLower,
+		"contains": strings.Contains,
+		"sortedStrings": func(values []string) []string {
+			out := append([]string(nil), values...)
+			sort.Strings(out)
+			return out
+		},
+	}
+}
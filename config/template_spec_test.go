@@ -0,0 +1,178 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/byteness/aws-vault/v7/policy"
+	"gopkg.in/yaml.v3"
+)
+
+func TestGenerateFromSpec_Basic(t *testing.T) {
+	spec := &Spec{
+		Template: TemplateBasic,
+		Profiles: []SpecProfile{
+			{Name: "dev", Users: []string{"alice"}},
+			{Name: "staging", Users: []string{"bob"}},
+		},
+	}
+
+	output, err := GenerateFromSpec(spec, "")
+	if err != nil {
+		t.Fatalf("GenerateFromSpec() error = %v", err)
+	}
+	if output.Approval != "" || output.BreakGlass != "" || output.RateLimit != "" {
+		t.Errorf("expected only a policy for the basic preset, got %+v", output)
+	}
+
+	var p policy.Policy
+	if err := yaml.Unmarshal([]byte(output.Policy), &p); err != nil {
+		t.Fatalf("failed to parse generated policy: %v", err)
+	}
+	if len(p.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(p.Rules))
+	}
+	for _, r := range p.Rules {
+		if r.Effect != policy.EffectAllow {
+			t.Errorf("rule %s effect = %s, want allow", r.Name, r.Effect)
+		}
+	}
+}
+
+func TestGenerateFromSpec_ApprovalsUsesApprovalChain(t *testing.T) {
+	spec := &Spec{
+		Template: TemplateApprovals,
+		ApprovalChains: []SpecApprovalChain{
+			{Name: "prod-chain", Approvers: []string{"alice", "bob"}},
+		},
+		Profiles: []SpecProfile{
+			{Name: "prod", Users: []string{"carol"}, ApprovalChain: "prod-chain"},
+		},
+	}
+
+	output, err := GenerateFromSpec(spec, "")
+	if err != nil {
+		t.Fatalf("GenerateFromSpec() error = %v", err)
+	}
+
+	var p policy.Policy
+	if err := yaml.Unmarshal([]byte(output.Policy), &p); err != nil {
+		t.Fatalf("failed to parse generated policy: %v", err)
+	}
+	if len(p.Rules) != 1 || p.Rules[0].Effect != policy.EffectRequireApproval {
+		t.Fatalf("expected one require_approval rule, got %+v", p.Rules)
+	}
+
+	var ap policy.ApprovalPolicy
+	if err := yaml.Unmarshal([]byte(output.Approval), &ap); err != nil {
+		t.Fatalf("failed to parse generated approval policy: %v", err)
+	}
+	if len(ap.Rules) != 1 {
+		t.Fatalf("expected 1 approval rule, got %d", len(ap.Rules))
+	}
+	if len(ap.Rules[0].Approvers) != 2 {
+		t.Errorf("Approvers = %v, want 2 approvers from prod-chain", ap.Rules[0].Approvers)
+	}
+}
+
+func TestGenerateFromSpec_FullRendersAllFourDocuments(t *testing.T) {
+	spec := &Spec{
+		Template: TemplateFull,
+		ApprovalChains: []SpecApprovalChain{
+			{Name: "chain1", Approvers: []string{"alice"}},
+		},
+		RateLimitTiers: []SpecRateLimitTier{
+			{Name: "tier1", Cooldown: "30m", MaxPerUser: 3, MaxPerProfile: 10, QuotaWindow: "24h"},
+		},
+		Profiles: []SpecProfile{
+			{Name: "prod", Users: []string{"bob"}, ApprovalChain: "chain1", RateLimitTier: "tier1"},
+		},
+	}
+
+	output, err := GenerateFromSpec(spec, "")
+	if err != nil {
+		t.Fatalf("GenerateFromSpec() error = %v", err)
+	}
+	if output.Policy == "" || output.Approval == "" || output.BreakGlass == "" || output.RateLimit == "" {
+		t.Fatalf("expected all four documents to be rendered, got %+v", output)
+	}
+	if !strings.Contains(output.RateLimit, "cooldown: 30m") {
+		t.Errorf("expected rate limit tier values in output, got: %s", output.RateLimit)
+	}
+}
+
+func TestGenerateFromSpec_UsesOverrideTemplateDir(t *testing.T) {
+	dir := t.TempDir()
+	presetDir := filepath.Join(dir, "basic")
+	if err := os.MkdirAll(presetDir, 0755); err != nil {
+		t.Fatalf("failed to create preset dir: %v", err)
+	}
+	tmplContent := `version: "1"
+rules:
+{{- range .Profiles }}
+  - name: custom-{{ .Name }}
+    effect: deny
+    conditions:
+      profiles:
+        - {{ .Name }}
+{{- end }}
+`
+	if err := os.WriteFile(filepath.Join(presetDir, "policy.yaml.tmpl"), []byte(tmplContent), 0644); err != nil {
+		t.Fatalf("failed to write override template: %v", err)
+	}
+
+	spec := &Spec{Template: TemplateBasic, Profiles: []SpecProfile{{Name: "dev"}}}
+
+	output, err := GenerateFromSpec(spec, dir)
+	if err != nil {
+		t.Fatalf("GenerateFromSpec() error = %v", err)
+	}
+	if !strings.Contains(output.Policy, "custom-dev") {
+		t.Errorf("expected override template to be used, got: %s", output.Policy)
+	}
+}
+
+func TestGenerateFromSpec_MissingOverrideDirErrors(t *testing.T) {
+	spec := &Spec{Template: TemplateBasic, Profiles: []SpecProfile{{Name: "dev"}}}
+
+	if _, err := GenerateFromSpec(spec, t.TempDir()); err == nil {
+		t.Error("expected an error when the override directory has no templates for the preset")
+	}
+}
+
+func TestGenerateFromSpec_NilSpecErrors(t *testing.T) {
+	if _, err := GenerateFromSpec(nil, ""); err == nil {
+		t.Error("expected an error for a nil spec")
+	}
+}
+
+func TestSpecValidate_ApprovalsRequiresApproversErrors(t *testing.T) {
+	spec := &Spec{
+		Template: TemplateApprovals,
+		Profiles: []SpecProfile{
+			{Name: "prod", Users: []string{"carol"}},
+		},
+	}
+
+	if err := spec.Validate(); err == nil {
+		t.Error("expected an error for an approvals profile with no approval_chain")
+	}
+}
+
+func TestSpecValidate_FullRequiresApproversErrors(t *testing.T) {
+	spec := &Spec{
+		Template: TemplateFull,
+		ApprovalChains: []SpecApprovalChain{
+			{Name: "empty-chain"},
+		},
+		Profiles: []SpecProfile{
+			{Name: "prod", Users: []string{"carol"}, ApprovalChain: "empty-chain"},
+		},
+	}
+
+	if err := spec.Validate(); err == nil {
+		t.Error("expected an error for a full profile whose approval_chain has no approvers")
+	}
+}
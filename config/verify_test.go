@@ -0,0 +1,67 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestCosignVerifier_Verify_RequiresPubKeyOrIdentity(t *testing.T) {
+	verifier := NewCosignVerifierWithRunner(&fakeCommandRunner{})
+
+	err := verifier.Verify(context.Background(), []byte("content"), VerifyOptions{})
+	if err == nil {
+		t.Error("expected an error when neither PubKeyPath nor FulcioIdentity is set")
+	}
+}
+
+func TestCosignVerifier_Verify_RunsCosignWithPubKey(t *testing.T) {
+	runner := &fakeCommandRunner{}
+	verifier := NewCosignVerifierWithRunner(runner)
+
+	err := verifier.Verify(context.Background(), []byte("content"), VerifyOptions{
+		SignaturePath: "policy.yaml.sig",
+		PubKeyPath:    "cosign.pub",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(runner.calls) != 1 {
+		t.Fatalf("expected 1 cosign invocation, got %d", len(runner.calls))
+	}
+	call := strings.Join(runner.calls[0], " ")
+	if !strings.Contains(call, "--key cosign.pub") || !strings.Contains(call, "--signature policy.yaml.sig") {
+		t.Errorf("unexpected cosign invocation: %s", call)
+	}
+}
+
+func TestCosignVerifier_Verify_RunsCosignWithFulcioIdentity(t *testing.T) {
+	runner := &fakeCommandRunner{}
+	verifier := NewCosignVerifierWithRunner(runner)
+
+	err := verifier.Verify(context.Background(), []byte("content"), VerifyOptions{
+		SignaturePath:  "policy.yaml.sig",
+		FulcioIdentity: "ci@example.com",
+		FulcioIssuer:   "https://issuer.example.com",
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	call := strings.Join(runner.calls[0], " ")
+	if !strings.Contains(call, "--certificate-identity ci@example.com") || !strings.Contains(call, "--certificate-oidc-issuer https://issuer.example.com") {
+		t.Errorf("unexpected cosign invocation: %s", call)
+	}
+}
+
+func TestCosignVerifier_Verify_CosignFailurePropagates(t *testing.T) {
+	runner := &fakeCommandRunner{err: errors.New("signature mismatch")}
+	verifier := NewCosignVerifierWithRunner(runner)
+
+	err := verifier.Verify(context.Background(), []byte("content"), VerifyOptions{PubKeyPath: "cosign.pub"})
+	if err == nil {
+		t.Error("expected an error when cosign fails")
+	}
+}
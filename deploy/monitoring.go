@@ -2,7 +2,9 @@ package deploy
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strings"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
@@ -18,22 +20,35 @@ const DefaultSNSTopicName = "sentinel-security-alerts"
 // DefaultMetricNamespace is the namespace for Sentinel CloudWatch metrics.
 const DefaultMetricNamespace = "Sentinel/Security"
 
+// pendingConfirmationSubscriptionARN is the SubscriptionArn SNS returns for a
+// subscription (e.g. https) that hasn't been confirmed by its endpoint yet.
+// It is a literal sentinel string, not a real ARN.
+const pendingConfirmationSubscriptionARN = "pending confirmation"
+
 // cloudwatchAlarmsAPI defines CloudWatch operations for alarm management.
 type cloudwatchAlarmsAPI interface {
 	PutMetricAlarm(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error)
 	DescribeAlarms(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error)
+	PutCompositeAlarm(ctx context.Context, params *cloudwatch.PutCompositeAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutCompositeAlarmOutput, error)
+	PutAnomalyDetector(ctx context.Context, params *cloudwatch.PutAnomalyDetectorInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutAnomalyDetectorOutput, error)
+	DeleteAlarms(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error)
 }
 
 // snsAPI defines SNS operations for topic management.
 type snsAPI interface {
 	CreateTopic(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error)
 	Subscribe(ctx context.Context, params *sns.SubscribeInput, optFns ...func(*sns.Options)) (*sns.SubscribeOutput, error)
+	SetSubscriptionAttributes(ctx context.Context, params *sns.SetSubscriptionAttributesInput, optFns ...func(*sns.Options)) (*sns.SetSubscriptionAttributesOutput, error)
+	DeleteTopic(ctx context.Context, params *sns.DeleteTopicInput, optFns ...func(*sns.Options)) (*sns.DeleteTopicOutput, error)
+	ListSubscriptionsByTopic(ctx context.Context, params *sns.ListSubscriptionsByTopicInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsByTopicOutput, error)
+	Unsubscribe(ctx context.Context, params *sns.UnsubscribeInput, optFns ...func(*sns.Options)) (*sns.UnsubscribeOutput, error)
 }
 
 // cloudwatchLogsMonitorAPI defines CloudWatch Logs operations for metric filters.
 type cloudwatchLogsMonitorAPI interface {
 	PutMetricFilter(ctx context.Context, params *cloudwatchlogs.PutMetricFilterInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutMetricFilterOutput, error)
 	DescribeMetricFilters(ctx context.Context, params *cloudwatchlogs.DescribeMetricFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error)
+	DeleteMetricFilter(ctx context.Context, params *cloudwatchlogs.DeleteMetricFilterInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteMetricFilterOutput, error)
 }
 
 // MonitoringSetup creates CloudWatch alarms for Sentinel security event monitoring.
@@ -45,15 +60,82 @@ type MonitoringSetup struct {
 
 // AlarmConfig describes a CloudWatch alarm to create.
 type AlarmConfig struct {
-	Name              string  `json:"name"`
-	Description       string  `json:"description"`
-	MetricName        string  `json:"metric_name"`
-	Namespace         string  `json:"namespace"`
-	Statistic         string  `json:"statistic"`
-	Period            int32   `json:"period"`
-	EvaluationPeriods int32   `json:"evaluation_periods"`
-	Threshold         float64 `json:"threshold"`
-	ComparisonOp      string  `json:"comparison_operator"`
+	Name              string `json:"name"`
+	Description       string `json:"description"`
+	MetricName        string `json:"metric_name"`
+	Namespace         string `json:"namespace"`
+	Statistic         string `json:"statistic"`
+	Period            int32  `json:"period"`
+	EvaluationPeriods int32  `json:"evaluation_periods"`
+	// Threshold is the static alarm threshold. It must be nil when AnomalyDetection
+	// is true - the band produced by PutAnomalyDetector is used instead.
+	Threshold    *float64 `json:"threshold,omitempty"`
+	ComparisonOp string   `json:"comparison_operator"`
+	// AnomalyDetection, when true, backs this alarm with a CloudWatch anomaly
+	// detection band rather than a static Threshold. CreateAlarm issues
+	// PutAnomalyDetector first and wires ThresholdMetricId to the resulting band.
+	AnomalyDetection bool `json:"anomaly_detection,omitempty"`
+}
+
+// CompositeAlarmConfig describes a CloudWatch composite alarm built from the
+// ALARM/OK/INSUFFICIENT_DATA states of other alarms (via PutCompositeAlarm).
+// Composite alarms let operators collapse several noisy metric alarms into a
+// single top-level incident notification.
+type CompositeAlarmConfig struct {
+	Name string `json:"name"`
+	// Description documents the composite alarm's purpose.
+	Description string `json:"description"`
+	// AlarmRule is the CloudWatch alarm rule expression, e.g.
+	// `ALARM("sentinel-kms-key-changes") OR ALARM("sentinel-ssm-delete")`.
+	AlarmRule string `json:"alarm_rule"`
+	// ActionsEnabled controls whether AlarmActions fire on state transitions.
+	ActionsEnabled bool `json:"actions_enabled"`
+	// AlarmActions are ARNs (typically the Sentinel SNS topic) notified on ALARM.
+	AlarmActions []string `json:"alarm_actions,omitempty"`
+}
+
+// NotificationProtocol identifies the SNS delivery protocol for a NotificationEndpoint.
+type NotificationProtocol string
+
+const (
+	// NotificationProtocolEmail delivers a human-readable email.
+	NotificationProtocolEmail NotificationProtocol = "email"
+	// NotificationProtocolEmailJSON delivers the raw JSON notification by email.
+	NotificationProtocolEmailJSON NotificationProtocol = "email-json"
+	// NotificationProtocolHTTPS delivers via HTTPS POST, used for Slack, PagerDuty,
+	// and other webhook-style integrations.
+	NotificationProtocolHTTPS NotificationProtocol = "https"
+	// NotificationProtocolSQS delivers to an SQS queue ARN.
+	NotificationProtocolSQS NotificationProtocol = "sqs"
+	// NotificationProtocolLambda invokes a Lambda function ARN directly.
+	NotificationProtocolLambda NotificationProtocol = "lambda"
+	// NotificationProtocolApplication delivers to a mobile push platform endpoint ARN.
+	NotificationProtocolApplication NotificationProtocol = "application"
+)
+
+// NotificationEndpoint describes a single SNS subscription to create as part of
+// monitoring setup. It generalizes the original email-only SubscribeEmail API so
+// a single Sentinel security topic can fan out to Slack, PagerDuty, SQS, Lambda,
+// and mobile push destinations.
+type NotificationEndpoint struct {
+	// Protocol is the SNS protocol to subscribe with.
+	Protocol NotificationProtocol
+	// Endpoint is the protocol-specific destination: an email address, an HTTPS
+	// URL, an SQS queue ARN, a Lambda function ARN, or a platform endpoint ARN.
+	Endpoint string
+	// FilterPolicy restricts delivery to messages whose attributes match, e.g.
+	// {"severity": {"critical"}}. Nil delivers every notification to this endpoint.
+	FilterPolicy map[string][]string
+	// RawMessageDelivery delivers the raw published message instead of wrapping it
+	// in the SNS JSON envelope. Most HTTPS and Lambda integrations expect this set.
+	RawMessageDelivery bool
+}
+
+// SubscriptionResult records the outcome of subscribing a single NotificationEndpoint.
+type SubscriptionResult struct {
+	Protocol        string `json:"protocol"`
+	Endpoint        string `json:"endpoint"`
+	SubscriptionARN string `json:"subscription_arn,omitempty"`
 }
 
 // MetricFilterConfig describes a CloudWatch Logs metric filter.
@@ -67,11 +149,15 @@ type MetricFilterConfig struct {
 
 // MonitoringResult contains the result of setting up monitoring.
 type MonitoringResult struct {
-	SNSTopicARN    string   `json:"sns_topic_arn"`
-	AlarmsCreated  []string `json:"alarms_created"`
-	FiltersCreated []string `json:"filters_created"`
-	AlarmsSkipped  []string `json:"alarms_skipped,omitempty"`
-	Errors         []string `json:"errors,omitempty"`
+	SNSTopicARN    string               `json:"sns_topic_arn"`
+	AlarmsCreated  []string             `json:"alarms_created"`
+	FiltersCreated []string             `json:"filters_created"`
+	AlarmsSkipped  []string             `json:"alarms_skipped,omitempty"`
+	Subscriptions  []SubscriptionResult `json:"subscriptions,omitempty"`
+	// CompositeAlarmsCreated lists composite alarms (e.g. "sentinel-security-incident")
+	// created from GetDefaultCompositeAlarms.
+	CompositeAlarmsCreated []string `json:"composite_alarms_created,omitempty"`
+	Errors                 []string `json:"errors,omitempty"`
 }
 
 // NewMonitoringSetup creates a new MonitoringSetup using the provided AWS configuration.
@@ -128,6 +214,121 @@ func (m *MonitoringSetup) SubscribeEmail(ctx context.Context, topicARN, email st
 	return nil
 }
 
+// SubscribeEndpoints adds one or more notification endpoints to the SNS topic,
+// generalizing SubscribeEmail to Slack, PagerDuty, HTTPS, SQS, Lambda, and mobile
+// push destinations. Each endpoint may carry a FilterPolicy so a single topic can
+// fan out per severity, e.g. KMS deletions to PagerDuty, DynamoDB deletes to Slack.
+// Subscription failures are accumulated rather than aborting the remaining endpoints.
+func (m *MonitoringSetup) SubscribeEndpoints(ctx context.Context, topicARN string, endpoints []NotificationEndpoint) ([]SubscriptionResult, error) {
+	results := make([]SubscriptionResult, 0, len(endpoints))
+	var errs []string
+
+	for _, ep := range endpoints {
+		output, err := m.sns.Subscribe(ctx, &sns.SubscribeInput{
+			TopicArn: aws.String(topicARN),
+			Protocol: aws.String(string(ep.Protocol)),
+			Endpoint: aws.String(ep.Endpoint),
+		})
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("failed to subscribe %s endpoint %s: %v", ep.Protocol, ep.Endpoint, err))
+			continue
+		}
+
+		result := SubscriptionResult{Protocol: string(ep.Protocol), Endpoint: ep.Endpoint}
+		if output.SubscriptionArn != nil {
+			result.SubscriptionARN = *output.SubscriptionArn
+		}
+
+		// Subscription attributes (filter policy, raw message delivery) only apply
+		// once the subscription is confirmed, which is immediate for lambda/sqs/
+		// application but pending for email and https until the endpoint confirms.
+		if result.SubscriptionARN != "" && result.SubscriptionARN != pendingConfirmationSubscriptionARN {
+			if ep.RawMessageDelivery {
+				if _, err := m.sns.SetSubscriptionAttributes(ctx, &sns.SetSubscriptionAttributesInput{
+					SubscriptionArn: aws.String(result.SubscriptionARN),
+					AttributeName:   aws.String("RawMessageDelivery"),
+					AttributeValue:  aws.String("true"),
+				}); err != nil {
+					errs = append(errs, fmt.Sprintf("failed to enable raw message delivery for %s: %v", ep.Endpoint, err))
+				}
+			}
+
+			if len(ep.FilterPolicy) > 0 {
+				policyJSON, err := json.Marshal(ep.FilterPolicy)
+				if err != nil {
+					errs = append(errs, fmt.Sprintf("failed to marshal filter policy for %s: %v", ep.Endpoint, err))
+				} else if _, err := m.sns.SetSubscriptionAttributes(ctx, &sns.SetSubscriptionAttributesInput{
+					SubscriptionArn: aws.String(result.SubscriptionARN),
+					AttributeName:   aws.String("FilterPolicy"),
+					AttributeValue:  aws.String(string(policyJSON)),
+				}); err != nil {
+					errs = append(errs, fmt.Sprintf("failed to set filter policy for %s: %v", ep.Endpoint, err))
+				}
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	if len(errs) > 0 {
+		return results, fmt.Errorf("subscription errors: %s", strings.Join(errs, "; "))
+	}
+	return results, nil
+}
+
+// NewSlackEndpoint builds a NotificationEndpoint that delivers alarm notifications
+// to the HTTPS endpoint of a notification-transform Lambda the operator has already
+// built and deployed from GenerateNotificationTransformLambda's scaffold, fronted by
+// a Function URL or API Gateway configured to post Slack Block Kit messages to
+// transformEndpointURL's destination. Raw message delivery is enabled so the
+// transform sees the unwrapped alarm JSON.
+func NewSlackEndpoint(transformEndpointURL string, filterPolicy map[string][]string) NotificationEndpoint {
+	return NotificationEndpoint{
+		Protocol:           NotificationProtocolHTTPS,
+		Endpoint:           transformEndpointURL,
+		FilterPolicy:       filterPolicy,
+		RawMessageDelivery: true,
+	}
+}
+
+// NewPagerDutyEndpoint builds a NotificationEndpoint that delivers alarm
+// notifications to the HTTPS endpoint of a notification-transform Lambda the
+// operator has already built and deployed, configured to convert CloudWatch
+// Alarm JSON into PagerDuty Events v2 payloads.
+func NewPagerDutyEndpoint(transformEndpointURL string, filterPolicy map[string][]string) NotificationEndpoint {
+	return NotificationEndpoint{
+		Protocol:           NotificationProtocolHTTPS,
+		Endpoint:           transformEndpointURL,
+		FilterPolicy:       filterPolicy,
+		RawMessageDelivery: true,
+	}
+}
+
+// NewLambdaEndpoint builds a NotificationEndpoint that subscribes a Lambda
+// function ARN directly to the topic, bypassing HTTPS subscription confirmation.
+// This is the recommended way to wire the notification-transform Lambda scaffold
+// (see GenerateNotificationTransformLambda) once the operator has deployed it in
+// the same account.
+func NewLambdaEndpoint(functionARN string, filterPolicy map[string][]string) NotificationEndpoint {
+	return NotificationEndpoint{
+		Protocol:     NotificationProtocolLambda,
+		Endpoint:     functionARN,
+		FilterPolicy: filterPolicy,
+	}
+}
+
+// GenerateNotificationTransformLambda returns the Go source for a Lambda function
+// that subscribes to the Sentinel security SNS topic and converts CloudWatch Alarm
+// JSON into Slack Block Kit or PagerDuty Events v2 payloads, selected at runtime by
+// the TRANSFORM_TARGET environment variable ("slack" or "pagerduty"). This is a
+// source-code scaffold only - SetupSentinelMonitoring does not build, package, or
+// deploy it. The operator must write it to a new cmd/lambda-notify-transform/main.go,
+// build and deploy it through their own CI/IaC, and only then point NewLambdaEndpoint
+// or NewSlackEndpoint/NewPagerDutyEndpoint at the resulting function ARN/URL.
+func GenerateNotificationTransformLambda() string {
+	return notificationTransformLambdaSource
+}
+
 // CreateMetricFilter creates a CloudWatch Logs metric filter for CloudTrail events.
 func (m *MonitoringSetup) CreateMetricFilter(ctx context.Context, config MetricFilterConfig) error {
 	_, err := m.cloudwatchLogs.PutMetricFilter(ctx, &cloudwatchlogs.PutMetricFilterInput{
@@ -152,6 +353,14 @@ func (m *MonitoringSetup) CreateMetricFilter(ctx context.Context, config MetricF
 
 // CreateAlarm creates a CloudWatch alarm for a metric.
 func (m *MonitoringSetup) CreateAlarm(ctx context.Context, config AlarmConfig, snsTopicARN string) error {
+	if config.AnomalyDetection {
+		return m.createAnomalyAlarm(ctx, config, snsTopicARN)
+	}
+
+	if config.Threshold == nil {
+		return fmt.Errorf("alarm %s: threshold is required unless AnomalyDetection is set", config.Name)
+	}
+
 	var comparisonOp cwtypes.ComparisonOperator
 	switch config.ComparisonOp {
 	case "GreaterThanOrEqualToThreshold":
@@ -166,21 +375,7 @@ func (m *MonitoringSetup) CreateAlarm(ctx context.Context, config AlarmConfig, s
 		comparisonOp = cwtypes.ComparisonOperatorGreaterThanOrEqualToThreshold
 	}
 
-	var statistic cwtypes.Statistic
-	switch config.Statistic {
-	case "Sum":
-		statistic = cwtypes.StatisticSum
-	case "Average":
-		statistic = cwtypes.StatisticAverage
-	case "Maximum":
-		statistic = cwtypes.StatisticMaximum
-	case "Minimum":
-		statistic = cwtypes.StatisticMinimum
-	case "SampleCount":
-		statistic = cwtypes.StatisticSampleCount
-	default:
-		statistic = cwtypes.StatisticSum
-	}
+	statistic := parseStatistic(config.Statistic)
 
 	input := &cloudwatch.PutMetricAlarmInput{
 		AlarmName:          aws.String(config.Name),
@@ -190,7 +385,7 @@ func (m *MonitoringSetup) CreateAlarm(ctx context.Context, config AlarmConfig, s
 		Statistic:          statistic,
 		Period:             aws.Int32(config.Period),
 		EvaluationPeriods:  aws.Int32(config.EvaluationPeriods),
-		Threshold:          aws.Float64(config.Threshold),
+		Threshold:          aws.Float64(*config.Threshold),
 		ComparisonOperator: comparisonOp,
 		TreatMissingData:   aws.String("notBreaching"),
 	}
@@ -208,6 +403,107 @@ func (m *MonitoringSetup) CreateAlarm(ctx context.Context, config AlarmConfig, s
 	return nil
 }
 
+// createAnomalyAlarm backs an alarm with an anomaly-detection band instead of a
+// static threshold. It issues PutAnomalyDetector for the metric first, then
+// creates the alarm against the resulting band with
+// ComparisonOperator=LessThanLowerOrGreaterThanUpperThreshold and
+// ThresholdMetricId pointing at the band's expression id.
+func (m *MonitoringSetup) createAnomalyAlarm(ctx context.Context, config AlarmConfig, snsTopicARN string) error {
+	statistic := parseStatistic(config.Statistic)
+
+	if _, err := m.cloudwatch.PutAnomalyDetector(ctx, &cloudwatch.PutAnomalyDetectorInput{
+		MetricName: aws.String(config.MetricName),
+		Namespace:  aws.String(config.Namespace),
+		Stat:       aws.String(string(statistic)),
+	}); err != nil {
+		return fmt.Errorf("failed to create anomaly detector for %s: %w", config.Name, err)
+	}
+
+	const metricID = "m1"
+	const bandID = "ad1"
+
+	input := &cloudwatch.PutMetricAlarmInput{
+		AlarmName:          aws.String(config.Name),
+		AlarmDescription:   aws.String(config.Description),
+		EvaluationPeriods:  aws.Int32(config.EvaluationPeriods),
+		ComparisonOperator: cwtypes.ComparisonOperatorLessThanLowerOrGreaterThanUpperThreshold,
+		ThresholdMetricId:  aws.String(bandID),
+		TreatMissingData:   aws.String("notBreaching"),
+		Metrics: []cwtypes.MetricDataQuery{
+			{
+				Id: aws.String(metricID),
+				MetricStat: &cwtypes.MetricStat{
+					Metric: &cwtypes.Metric{
+						Namespace:  aws.String(config.Namespace),
+						MetricName: aws.String(config.MetricName),
+					},
+					Period: aws.Int32(config.Period),
+					Stat:   aws.String(string(statistic)),
+				},
+				ReturnData: aws.Bool(false),
+			},
+			{
+				Id:         aws.String(bandID),
+				Expression: aws.String(fmt.Sprintf("ANOMALY_DETECTION_BAND(%s, 2)", metricID)),
+				Label:      aws.String(config.Name + " (expected range)"),
+				ReturnData: aws.Bool(true),
+			},
+		},
+	}
+
+	if snsTopicARN != "" {
+		input.AlarmActions = []string{snsTopicARN}
+	}
+
+	if _, err := m.cloudwatch.PutMetricAlarm(ctx, input); err != nil {
+		return fmt.Errorf("failed to create anomaly alarm %s: %w", config.Name, err)
+	}
+
+	return nil
+}
+
+// parseStatistic maps a config statistic name to the CloudWatch enum, defaulting
+// to Sum for unrecognized values (matching the previous inline switch behavior).
+func parseStatistic(statistic string) cwtypes.Statistic {
+	switch statistic {
+	case "Sum":
+		return cwtypes.StatisticSum
+	case "Average":
+		return cwtypes.StatisticAverage
+	case "Maximum":
+		return cwtypes.StatisticMaximum
+	case "Minimum":
+		return cwtypes.StatisticMinimum
+	case "SampleCount":
+		return cwtypes.StatisticSampleCount
+	default:
+		return cwtypes.StatisticSum
+	}
+}
+
+// CreateCompositeAlarm creates a CloudWatch composite alarm that aggregates the
+// ALARM state of other alarms via an AlarmRule expression, e.g.
+// `ALARM("sentinel-kms-key-changes") OR ALARM("sentinel-ssm-delete")`.
+func (m *MonitoringSetup) CreateCompositeAlarm(ctx context.Context, config CompositeAlarmConfig) error {
+	input := &cloudwatch.PutCompositeAlarmInput{
+		AlarmName:        aws.String(config.Name),
+		AlarmDescription: aws.String(config.Description),
+		AlarmRule:        aws.String(config.AlarmRule),
+		ActionsEnabled:   aws.Bool(config.ActionsEnabled),
+	}
+
+	if len(config.AlarmActions) > 0 {
+		input.AlarmActions = config.AlarmActions
+	}
+
+	_, err := m.cloudwatch.PutCompositeAlarm(ctx, input)
+	if err != nil {
+		return fmt.Errorf("failed to create composite alarm %s: %w", config.Name, err)
+	}
+
+	return nil
+}
+
 // GetDefaultMetricFilters returns the standard metric filter configurations for Sentinel monitoring.
 // These filters detect security-critical CloudTrail events.
 func GetDefaultMetricFilters(logGroupName string) []MetricFilterConfig {
@@ -255,7 +551,7 @@ func GetDefaultAlarms() []AlarmConfig {
 			Statistic:         "Sum",
 			Period:            300, // 5 minutes
 			EvaluationPeriods: 1,
-			Threshold:         1,
+			Threshold:         aws.Float64(1),
 			ComparisonOp:      "GreaterThanOrEqualToThreshold",
 		},
 		{
@@ -266,7 +562,7 @@ func GetDefaultAlarms() []AlarmConfig {
 			Statistic:         "Sum",
 			Period:            300,
 			EvaluationPeriods: 1,
-			Threshold:         1,
+			Threshold:         aws.Float64(1),
 			ComparisonOp:      "GreaterThanOrEqualToThreshold",
 		},
 		{
@@ -277,7 +573,7 @@ func GetDefaultAlarms() []AlarmConfig {
 			Statistic:         "Sum",
 			Period:            300,
 			EvaluationPeriods: 1,
-			Threshold:         1,
+			Threshold:         aws.Float64(1),
 			ComparisonOp:      "GreaterThanOrEqualToThreshold",
 		},
 		{
@@ -288,7 +584,7 @@ func GetDefaultAlarms() []AlarmConfig {
 			Statistic:         "Sum",
 			Period:            300,
 			EvaluationPeriods: 1,
-			Threshold:         1,
+			Threshold:         aws.Float64(1),
 			ComparisonOp:      "GreaterThanOrEqualToThreshold",
 		},
 	}
@@ -304,9 +600,33 @@ func GetAlarmNames() []string {
 	}
 }
 
+// GetDefaultCompositeAlarms returns the standard composite alarm built on top of
+// GetDefaultAlarms. The single "sentinel-security-incident" composite fires when
+// any individual Sentinel alarm is in ALARM state, so operators get one page
+// instead of one per underlying metric.
+func GetDefaultCompositeAlarms() []CompositeAlarmConfig {
+	names := GetAlarmNames()
+	clauses := make([]string, len(names))
+	for i, name := range names {
+		clauses[i] = fmt.Sprintf("ALARM(%q)", name)
+	}
+
+	return []CompositeAlarmConfig{
+		{
+			Name:           "sentinel-security-incident",
+			Description:    "Fires when any individual Sentinel security alarm enters ALARM state",
+			AlarmRule:      strings.Join(clauses, " OR "),
+			ActionsEnabled: true,
+		},
+	}
+}
+
 // SetupSentinelMonitoring creates all recommended alarms for Sentinel infrastructure.
 // Requires CloudTrail log group name where CloudTrail events are delivered.
-func (m *MonitoringSetup) SetupSentinelMonitoring(ctx context.Context, cloudTrailLogGroup string, snsTopicName string, email string) (*MonitoringResult, error) {
+// endpoints is variadic so existing callers built against the email-only API keep
+// compiling; pass NewSlackEndpoint/NewPagerDutyEndpoint/NewLambdaEndpoint results
+// (or a hand-built NotificationEndpoint) to fan out beyond a single email address.
+func (m *MonitoringSetup) SetupSentinelMonitoring(ctx context.Context, cloudTrailLogGroup string, snsTopicName string, email string, endpoints ...NotificationEndpoint) (*MonitoringResult, error) {
 	result := &MonitoringResult{
 		AlarmsCreated:  []string{},
 		FiltersCreated: []string{},
@@ -328,6 +648,15 @@ func (m *MonitoringSetup) SetupSentinelMonitoring(ctx context.Context, cloudTrai
 		}
 	}
 
+	// Subscribe additional notification endpoints (Slack, PagerDuty, SQS, Lambda, ...)
+	if len(endpoints) > 0 {
+		subscriptions, err := m.SubscribeEndpoints(ctx, topicARN, endpoints)
+		result.Subscriptions = subscriptions
+		if err != nil {
+			result.Errors = append(result.Errors, err.Error())
+		}
+	}
+
 	// Create metric filters
 	filters := GetDefaultMetricFilters(cloudTrailLogGroup)
 	for _, filter := range filters {
@@ -356,6 +685,23 @@ func (m *MonitoringSetup) SetupSentinelMonitoring(ctx context.Context, cloudTrai
 		result.AlarmsCreated = append(result.AlarmsCreated, alarm.Name)
 	}
 
+	// Create the top-level composite alarm that collapses the alarms above into
+	// a single incident notification.
+	for _, composite := range GetDefaultCompositeAlarms() {
+		if topicARN != "" {
+			composite.AlarmActions = []string{topicARN}
+		}
+		if err := m.CreateCompositeAlarm(ctx, composite); err != nil {
+			if isAccessDenied(err) {
+				result.Errors = append(result.Errors, fmt.Sprintf("access denied creating composite alarm %s", composite.Name))
+				continue
+			}
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to create composite alarm %s: %v", composite.Name, err))
+			continue
+		}
+		result.CompositeAlarmsCreated = append(result.CompositeAlarmsCreated, composite.Name)
+	}
+
 	return result, nil
 }
 
@@ -426,3 +772,122 @@ func (m *MonitoringSetup) SetupSelectedAlarms(ctx context.Context, cloudTrailLog
 
 	return result, nil
 }
+
+// notificationTransformLambdaSource is the scaffold returned by
+// GenerateNotificationTransformLambda. It is intentionally minimal - teams are
+// expected to copy it into cmd/lambda-notify-transform, build and deploy it
+// themselves (this package never calls the Lambda API), and fill in
+// Slack/PagerDuty credentials via environment variables or Sentinel's
+// SSM-backed secrets.
+const notificationTransformLambdaSource = `// Package main converts CloudWatch Alarm notifications delivered over SNS into
+// Slack Block Kit or PagerDuty Events v2 payloads, selected by TRANSFORM_TARGET.
+//
+// Build and deploy this yourself alongside SetupSentinelMonitoring, then subscribe
+// it with deploy.NewLambdaEndpoint (direct SNS->Lambda) or front it with a Function URL /
+// API Gateway and subscribe that with deploy.NewSlackEndpoint / NewPagerDutyEndpoint.
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+// cloudWatchAlarmMessage is the JSON body CloudWatch publishes to SNS for an alarm
+// state change.
+type cloudWatchAlarmMessage struct {
+	AlarmName      string ` + "`json:\"AlarmName\"`" + `
+	NewStateValue  string ` + "`json:\"NewStateValue\"`" + `
+	NewStateReason string ` + "`json:\"NewStateReason\"`" + `
+}
+
+func main() {
+	lambda.Start(handleSNSEvent)
+}
+
+// handleSNSEvent transforms each SNS record's alarm JSON into the destination
+// chat-ops payload and POSTs it to the configured webhook/events URL.
+func handleSNSEvent(ctx context.Context, snsEvent events.SNSEvent) error {
+	target := os.Getenv("TRANSFORM_TARGET")
+	webhookURL := os.Getenv("WEBHOOK_URL")
+
+	for _, record := range snsEvent.Records {
+		var alarm cloudWatchAlarmMessage
+		if err := json.Unmarshal([]byte(record.SNS.Message), &alarm); err != nil {
+			return fmt.Errorf("failed to parse alarm message: %w", err)
+		}
+
+		var payload []byte
+		var err error
+		switch target {
+		case "pagerduty":
+			payload, err = pagerDutyPayload(alarm)
+		default:
+			payload, err = slackPayload(alarm)
+		}
+		if err != nil {
+			return fmt.Errorf("failed to build %s payload: %w", target, err)
+		}
+
+		if err := postJSON(ctx, webhookURL, payload); err != nil {
+			return fmt.Errorf("failed to deliver %s notification: %w", target, err)
+		}
+	}
+
+	return nil
+}
+
+// slackPayload renders the alarm as a Slack Block Kit message.
+func slackPayload(alarm cloudWatchAlarmMessage) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"blocks": []map[string]interface{}{
+			{
+				"type": "section",
+				"text": map[string]string{
+					"type": "mrkdwn",
+					"text": fmt.Sprintf("*%s*\n%s", alarm.AlarmName, alarm.NewStateReason),
+				},
+			},
+		},
+	})
+}
+
+// pagerDutyPayload renders the alarm as a PagerDuty Events v2 trigger event.
+func pagerDutyPayload(alarm cloudWatchAlarmMessage) ([]byte, error) {
+	return json.Marshal(map[string]interface{}{
+		"routing_key":  os.Getenv("PAGERDUTY_ROUTING_KEY"),
+		"event_action": "trigger",
+		"payload": map[string]string{
+			"summary":  fmt.Sprintf("%s: %s", alarm.AlarmName, alarm.NewStateReason),
+			"source":   "sentinel",
+			"severity": "critical",
+		},
+	})
+}
+
+// postJSON delivers the payload to the destination webhook/events URL.
+func postJSON(ctx context.Context, url string, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+`
@@ -0,0 +1,315 @@
+package deploy
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+// TeardownResult contains the result of tearing down Sentinel monitoring resources.
+type TeardownResult struct {
+	CompositeAlarmsDeleted []string `json:"composite_alarms_deleted,omitempty"`
+	AlarmsDeleted          []string `json:"alarms_deleted"`
+	FiltersDeleted         []string `json:"filters_deleted"`
+	SubscriptionsRemoved   []string `json:"subscriptions_removed,omitempty"`
+	TopicDeleted           string   `json:"topic_deleted,omitempty"`
+	Errors                 []string `json:"errors,omitempty"`
+}
+
+// TeardownSentinelMonitoring removes monitoring resources previously created by
+// SetupSentinelMonitoring, enumerating them live rather than relying on a local
+// record so the operation is idempotent even if run against a partially-deployed
+// or drifted account. Resources are deleted in dependency order - composite
+// alarms first (CloudWatch rejects deleting an alarm referenced by a composite
+// that is still in OK/ALARM state with actions enabled), then metric alarms,
+// then metric filters, then SNS subscriptions, then the topic itself - and a
+// failure at one stage stops the stages that depend on it while still reporting
+// what was already removed, mirroring the partial-failure accumulation in
+// SetupSentinelMonitoring.
+func (m *MonitoringSetup) TeardownSentinelMonitoring(ctx context.Context, prefix string) (*TeardownResult, error) {
+	if prefix == "" {
+		prefix = "sentinel-"
+	}
+
+	result := &TeardownResult{
+		AlarmsDeleted:  []string{},
+		FiltersDeleted: []string{},
+	}
+
+	alarmNames, compositeNames, err := m.listAlarmsByPrefix(ctx, prefix)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to list alarms: %v", err))
+		return result, fmt.Errorf("teardown aborted: %w", err)
+	}
+
+	if len(compositeNames) > 0 {
+		if _, err := m.cloudwatch.DeleteAlarms(ctx, &cloudwatch.DeleteAlarmsInput{AlarmNames: compositeNames}); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to delete composite alarms: %v", err))
+		} else {
+			result.CompositeAlarmsDeleted = compositeNames
+		}
+	}
+
+	if len(alarmNames) > 0 && len(compositeNames) > 0 && result.CompositeAlarmsDeleted == nil {
+		result.Errors = append(result.Errors, "skipped deleting alarms: composite alarm deletion failed")
+	} else if len(alarmNames) > 0 {
+		if _, err := m.cloudwatch.DeleteAlarms(ctx, &cloudwatch.DeleteAlarmsInput{AlarmNames: alarmNames}); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to delete alarms: %v", err))
+		} else {
+			result.AlarmsDeleted = alarmNames
+		}
+	}
+
+	filterNames, err := m.listMetricFiltersByPrefix(ctx, prefix)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to list metric filters: %v", err))
+	}
+	for _, filter := range filterNames {
+		if _, err := m.cloudwatchLogs.DeleteMetricFilter(ctx, &cloudwatchlogs.DeleteMetricFilterInput{
+			LogGroupName: aws.String(filter.logGroupName),
+			FilterName:   aws.String(filter.name),
+		}); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to delete metric filter %s: %v", filter.name, err))
+			continue
+		}
+		result.FiltersDeleted = append(result.FiltersDeleted, filter.name)
+	}
+
+	topicARN, err := m.CreateOrGetSNSTopic(ctx, DefaultSNSTopicName)
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to resolve SNS topic: %v", err))
+		return result, nil
+	}
+
+	subs, err := m.sns.ListSubscriptionsByTopic(ctx, &sns.ListSubscriptionsByTopicInput{TopicArn: aws.String(topicARN)})
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to list subscriptions: %v", err))
+	} else {
+		for _, sub := range subs.Subscriptions {
+			if sub.SubscriptionArn == nil || *sub.SubscriptionArn == pendingConfirmationSubscriptionARN {
+				continue
+			}
+			if _, err := m.sns.Unsubscribe(ctx, &sns.UnsubscribeInput{SubscriptionArn: sub.SubscriptionArn}); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to unsubscribe %s: %v", *sub.SubscriptionArn, err))
+				continue
+			}
+			result.SubscriptionsRemoved = append(result.SubscriptionsRemoved, *sub.SubscriptionArn)
+		}
+	}
+
+	if _, err := m.sns.DeleteTopic(ctx, &sns.DeleteTopicInput{TopicArn: aws.String(topicARN)}); err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("failed to delete SNS topic: %v", err))
+	} else {
+		result.TopicDeleted = topicARN
+	}
+
+	return result, nil
+}
+
+// listAlarmsByPrefix separates alarm names matching prefix into ordinary metric
+// alarms and composite alarms, since they must be deleted in that order.
+func (m *MonitoringSetup) listAlarmsByPrefix(ctx context.Context, prefix string) (alarmNames, compositeNames []string, err error) {
+	output, err := m.cloudwatch.DescribeAlarms(ctx, &cloudwatch.DescribeAlarmsInput{
+		AlarmNamePrefix: aws.String(prefix),
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	for _, alarm := range output.MetricAlarms {
+		if alarm.AlarmName != nil {
+			alarmNames = append(alarmNames, *alarm.AlarmName)
+		}
+	}
+	for _, alarm := range output.CompositeAlarms {
+		if alarm.AlarmName != nil {
+			compositeNames = append(compositeNames, *alarm.AlarmName)
+		}
+	}
+
+	return alarmNames, compositeNames, nil
+}
+
+// metricFilterRef identifies a metric filter by its log group and filter name,
+// both of which DeleteMetricFilter requires.
+type metricFilterRef struct {
+	logGroupName string
+	name         string
+}
+
+// listMetricFiltersByPrefix lists metric filters whose name matches prefix.
+func (m *MonitoringSetup) listMetricFiltersByPrefix(ctx context.Context, prefix string) ([]metricFilterRef, error) {
+	output, err := m.cloudwatchLogs.DescribeMetricFilters(ctx, &cloudwatchlogs.DescribeMetricFiltersInput{
+		FilterNamePrefix: aws.String(prefix),
+		MetricNamespace:  aws.String(DefaultMetricNamespace),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refs := make([]metricFilterRef, 0, len(output.MetricFilters))
+	for _, f := range output.MetricFilters {
+		if f.FilterName == nil || f.LogGroupName == nil {
+			continue
+		}
+		refs = append(refs, metricFilterRef{logGroupName: *f.LogGroupName, name: *f.FilterName})
+	}
+
+	return refs, nil
+}
+
+// FieldDrift describes a single configuration field that no longer matches what
+// GetDefaultAlarms/GetDefaultMetricFilters would produce.
+type FieldDrift struct {
+	Field    string `json:"field"`
+	Expected string `json:"expected"`
+	Actual   string `json:"actual"`
+}
+
+// AlarmDrift reports out-of-band changes to a single alarm.
+type AlarmDrift struct {
+	Name    string       `json:"name"`
+	Changes []FieldDrift `json:"changes"`
+}
+
+// FilterDrift reports out-of-band changes to a single metric filter.
+type FilterDrift struct {
+	Name    string       `json:"name"`
+	Changes []FieldDrift `json:"changes"`
+}
+
+// DriftReport is the result of comparing live monitoring configuration against
+// GetDefaultAlarms/GetDefaultMetricFilters. An empty report (InSync=true) means
+// every tracked resource still matches what SetupSentinelMonitoring would create.
+type DriftReport struct {
+	AlarmDrift  []AlarmDrift  `json:"alarm_drift,omitempty"`
+	FilterDrift []FilterDrift `json:"filter_drift,omitempty"`
+	InSync      bool          `json:"in_sync"`
+}
+
+// DetectDrift compares the live configuration of each default alarm and metric
+// filter against GetDefaultAlarms/GetDefaultMetricFilters, reporting fields that
+// were changed out-of-band - e.g. someone raised a threshold to 100 to silence
+// an alarm. cloudTrailLogGroup must match the log group metric filters were
+// created against, since DescribeMetricFilters is scoped per log group. The
+// returned report is suitable for CI gating via report.InSync.
+func (m *MonitoringSetup) DetectDrift(ctx context.Context, cloudTrailLogGroup string) (*DriftReport, error) {
+	report := &DriftReport{}
+
+	liveAlarms, err := m.cloudwatch.DescribeAlarms(ctx, &cloudwatch.DescribeAlarmsInput{
+		AlarmNamePrefix: aws.String("sentinel-"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe alarms: %w", err)
+	}
+	liveAlarmsByName := make(map[string]cwMetricAlarm, len(liveAlarms.MetricAlarms))
+	for _, a := range liveAlarms.MetricAlarms {
+		if a.AlarmName == nil {
+			continue
+		}
+		liveAlarmsByName[*a.AlarmName] = cwMetricAlarm{
+			Threshold:          a.Threshold,
+			Period:             a.Period,
+			ComparisonOperator: string(a.ComparisonOperator),
+			TreatMissingData:   aws.ToString(a.TreatMissingData),
+			HasSNSAction:       len(a.AlarmActions) > 0,
+		}
+	}
+
+	for _, expected := range GetDefaultAlarms() {
+		live, ok := liveAlarmsByName[expected.Name]
+		if !ok {
+			report.AlarmDrift = append(report.AlarmDrift, AlarmDrift{
+				Name:    expected.Name,
+				Changes: []FieldDrift{{Field: "existence", Expected: "present", Actual: "missing"}},
+			})
+			continue
+		}
+
+		var changes []FieldDrift
+		if expected.Threshold != nil && (live.Threshold == nil || *live.Threshold != *expected.Threshold) {
+			changes = append(changes, FieldDrift{
+				Field:    "threshold",
+				Expected: fmt.Sprintf("%v", *expected.Threshold),
+				Actual:   fmt.Sprintf("%v", aws.ToFloat64(live.Threshold)),
+			})
+		}
+		if live.Period == nil || *live.Period != expected.Period {
+			changes = append(changes, FieldDrift{
+				Field:    "period",
+				Expected: fmt.Sprintf("%d", expected.Period),
+				Actual:   fmt.Sprintf("%d", aws.ToInt32(live.Period)),
+			})
+		}
+		if !live.HasSNSAction {
+			changes = append(changes, FieldDrift{Field: "sns_action", Expected: "present", Actual: "missing"})
+		}
+		if live.TreatMissingData != "notBreaching" {
+			changes = append(changes, FieldDrift{Field: "treat_missing_data", Expected: "notBreaching", Actual: live.TreatMissingData})
+		}
+
+		if len(changes) > 0 {
+			report.AlarmDrift = append(report.AlarmDrift, AlarmDrift{Name: expected.Name, Changes: changes})
+		}
+	}
+
+	liveFilters, err := m.cloudwatchLogs.DescribeMetricFilters(ctx, &cloudwatchlogs.DescribeMetricFiltersInput{
+		LogGroupName:     aws.String(cloudTrailLogGroup),
+		FilterNamePrefix: aws.String("sentinel-"),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to describe metric filters: %w", err)
+	}
+	liveFiltersByName := make(map[string]string, len(liveFilters.MetricFilters))
+	for _, f := range liveFilters.MetricFilters {
+		if f.FilterName == nil {
+			continue
+		}
+		liveFiltersByName[*f.FilterName] = aws.ToString(f.FilterPattern)
+	}
+
+	for _, expected := range GetDefaultMetricFilters(cloudTrailLogGroup) {
+		livePattern, ok := liveFiltersByName[expected.Name]
+		if !ok {
+			report.FilterDrift = append(report.FilterDrift, FilterDrift{
+				Name:    expected.Name,
+				Changes: []FieldDrift{{Field: "existence", Expected: "present", Actual: "missing"}},
+			})
+			continue
+		}
+		if normalizeFilterPattern(livePattern) != normalizeFilterPattern(expected.FilterPattern) {
+			report.FilterDrift = append(report.FilterDrift, FilterDrift{
+				Name: expected.Name,
+				Changes: []FieldDrift{{
+					Field:    "filter_pattern",
+					Expected: expected.FilterPattern,
+					Actual:   livePattern,
+				}},
+			})
+		}
+	}
+
+	report.InSync = len(report.AlarmDrift) == 0 && len(report.FilterDrift) == 0
+	return report, nil
+}
+
+// cwMetricAlarm is the subset of a live CloudWatch alarm's fields DetectDrift reads.
+// ComparisonOperator is recorded but not compared.
+type cwMetricAlarm struct {
+	Threshold          *float64
+	Period             *int32
+	ComparisonOperator string
+	TreatMissingData   string
+	HasSNSAction       bool
+}
+
+// normalizeFilterPattern collapses whitespace so cosmetic formatting differences
+// (CloudWatch normalizes patterns server-side) don't register as drift.
+func normalizeFilterPattern(pattern string) string {
+	return strings.Join(strings.Fields(pattern), " ")
+}
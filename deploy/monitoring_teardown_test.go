@@ -0,0 +1,244 @@
+package deploy
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	cwltypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	snstypes "github.com/aws/aws-sdk-go-v2/service/sns/types"
+)
+
+func TestMonitoringSetup_TeardownSentinelMonitoring_DeletesInDependencyOrder(t *testing.T) {
+	ctx := context.Background()
+
+	var deletedAlarmBatches [][]string
+	cwClient := &mockCloudWatchAlarmsClient{
+		DescribeAlarmsFunc: func(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error) {
+			return &cloudwatch.DescribeAlarmsOutput{
+				MetricAlarms:    []cwtypes.MetricAlarm{{AlarmName: aws.String("sentinel-kms-key-changes")}},
+				CompositeAlarms: []cwtypes.CompositeAlarm{{AlarmName: aws.String("sentinel-security-incident")}},
+			}, nil
+		},
+		DeleteAlarmsFunc: func(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error) {
+			deletedAlarmBatches = append(deletedAlarmBatches, params.AlarmNames)
+			return &cloudwatch.DeleteAlarmsOutput{}, nil
+		},
+	}
+
+	var deletedFilter string
+	cwLogsClient := &mockCloudWatchLogsClient{
+		DescribeMetricFiltersFunc: func(ctx context.Context, params *cloudwatchlogs.DescribeMetricFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+			return &cloudwatchlogs.DescribeMetricFiltersOutput{
+				MetricFilters: []cwltypes.MetricFilter{
+					{FilterName: aws.String("sentinel-kms-key-changes"), LogGroupName: aws.String("/aws/cloudtrail/sentinel")},
+				},
+			}, nil
+		},
+		DeleteMetricFilterFunc: func(ctx context.Context, params *cloudwatchlogs.DeleteMetricFilterInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteMetricFilterOutput, error) {
+			deletedFilter = *params.FilterName
+			return &cloudwatchlogs.DeleteMetricFilterOutput{}, nil
+		},
+	}
+
+	topicDeleted := false
+	unsubscribed := ""
+	snsClient := &mockSNSClient{
+		CreateTopicFunc: func(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error) {
+			return &sns.CreateTopicOutput{TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts")}, nil
+		},
+		ListSubscriptionsByTopicFunc: func(ctx context.Context, params *sns.ListSubscriptionsByTopicInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsByTopicOutput, error) {
+			return &sns.ListSubscriptionsByTopicOutput{
+				Subscriptions: []snstypes.Subscription{
+					{SubscriptionArn: aws.String("arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts:sub-1")},
+				},
+			}, nil
+		},
+		UnsubscribeFunc: func(ctx context.Context, params *sns.UnsubscribeInput, optFns ...func(*sns.Options)) (*sns.UnsubscribeOutput, error) {
+			unsubscribed = *params.SubscriptionArn
+			return &sns.UnsubscribeOutput{}, nil
+		},
+		DeleteTopicFunc: func(ctx context.Context, params *sns.DeleteTopicInput, optFns ...func(*sns.Options)) (*sns.DeleteTopicOutput, error) {
+			topicDeleted = true
+			return &sns.DeleteTopicOutput{}, nil
+		},
+	}
+
+	setup := NewMonitoringSetupWithClients(cwClient, snsClient, cwLogsClient)
+
+	result, err := setup.TeardownSentinelMonitoring(ctx, "sentinel-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(deletedAlarmBatches) != 2 {
+		t.Fatalf("expected composite alarms and metric alarms deleted separately, got %d batches", len(deletedAlarmBatches))
+	}
+	if len(result.CompositeAlarmsDeleted) != 1 || result.CompositeAlarmsDeleted[0] != "sentinel-security-incident" {
+		t.Errorf("expected composite alarm deletion recorded, got %v", result.CompositeAlarmsDeleted)
+	}
+	if len(result.AlarmsDeleted) != 1 || result.AlarmsDeleted[0] != "sentinel-kms-key-changes" {
+		t.Errorf("expected metric alarm deletion recorded, got %v", result.AlarmsDeleted)
+	}
+	if deletedFilter != "sentinel-kms-key-changes" {
+		t.Errorf("expected metric filter deleted, got %q", deletedFilter)
+	}
+	if unsubscribed == "" {
+		t.Error("expected subscription to be removed")
+	}
+	if !topicDeleted {
+		t.Error("expected topic to be deleted")
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("expected no errors, got %v", result.Errors)
+	}
+}
+
+func TestMonitoringSetup_TeardownSentinelMonitoring_AccumulatesPartialFailures(t *testing.T) {
+	ctx := context.Background()
+
+	cwClient := &mockCloudWatchAlarmsClient{
+		DescribeAlarmsFunc: func(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error) {
+			return &cloudwatch.DescribeAlarmsOutput{
+				MetricAlarms: []cwtypes.MetricAlarm{{AlarmName: aws.String("sentinel-kms-key-changes")}},
+			}, nil
+		},
+		DeleteAlarmsFunc: func(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error) {
+			return nil, errors.New("access denied")
+		},
+	}
+
+	snsClient := &mockSNSClient{
+		CreateTopicFunc: func(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error) {
+			return &sns.CreateTopicOutput{TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts")}, nil
+		},
+	}
+
+	setup := NewMonitoringSetupWithClients(cwClient, snsClient, &mockCloudWatchLogsClient{})
+
+	result, err := setup.TeardownSentinelMonitoring(ctx, "sentinel-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Errors) == 0 {
+		t.Error("expected alarm deletion failure to be recorded in Errors")
+	}
+	if result.TopicDeleted == "" {
+		t.Error("expected teardown to continue past the alarm failure and delete the topic")
+	}
+}
+
+func TestMonitoringSetup_DetectDrift_ReportsChangedThreshold(t *testing.T) {
+	ctx := context.Background()
+
+	cwClient := &mockCloudWatchAlarmsClient{
+		DescribeAlarmsFunc: func(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error) {
+			var alarms []cwtypes.MetricAlarm
+			for _, expected := range GetDefaultAlarms() {
+				threshold := aws.ToFloat64(expected.Threshold)
+				if expected.Name == "sentinel-kms-key-changes" {
+					threshold = 100
+				}
+				alarms = append(alarms, cwtypes.MetricAlarm{
+					AlarmName:        aws.String(expected.Name),
+					Threshold:        aws.Float64(threshold),
+					Period:           aws.Int32(expected.Period),
+					TreatMissingData: aws.String("notBreaching"),
+					AlarmActions:     []string{"arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts"},
+				})
+			}
+			return &cloudwatch.DescribeAlarmsOutput{MetricAlarms: alarms}, nil
+		},
+	}
+
+	cwLogsClient := &mockCloudWatchLogsClient{
+		DescribeMetricFiltersFunc: func(ctx context.Context, params *cloudwatchlogs.DescribeMetricFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+			var filters []cwltypes.MetricFilter
+			for _, expected := range GetDefaultMetricFilters("/aws/cloudtrail/sentinel") {
+				filters = append(filters, cwltypes.MetricFilter{
+					FilterName:    aws.String(expected.Name),
+					FilterPattern: aws.String(expected.FilterPattern),
+				})
+			}
+			return &cloudwatchlogs.DescribeMetricFiltersOutput{MetricFilters: filters}, nil
+		},
+	}
+
+	setup := NewMonitoringSetupWithClients(cwClient, &mockSNSClient{}, cwLogsClient)
+
+	report, err := setup.DetectDrift(ctx, "/aws/cloudtrail/sentinel")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.InSync {
+		t.Fatal("expected drift to be detected")
+	}
+	if len(report.FilterDrift) != 0 {
+		t.Errorf("expected no filter drift, got %v", report.FilterDrift)
+	}
+
+	found := false
+	for _, drift := range report.AlarmDrift {
+		if drift.Name != "sentinel-kms-key-changes" {
+			continue
+		}
+		found = true
+		if len(drift.Changes) != 1 || drift.Changes[0].Field != "threshold" {
+			t.Errorf("expected a single threshold change, got %v", drift.Changes)
+		}
+	}
+	if !found {
+		t.Error("expected drift entry for sentinel-kms-key-changes")
+	}
+}
+
+func TestMonitoringSetup_DetectDrift_InSyncWhenConfigurationMatches(t *testing.T) {
+	ctx := context.Background()
+
+	cwClient := &mockCloudWatchAlarmsClient{
+		DescribeAlarmsFunc: func(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error) {
+			var alarms []cwtypes.MetricAlarm
+			for _, expected := range GetDefaultAlarms() {
+				alarms = append(alarms, cwtypes.MetricAlarm{
+					AlarmName:        aws.String(expected.Name),
+					Threshold:        expected.Threshold,
+					Period:           aws.Int32(expected.Period),
+					TreatMissingData: aws.String("notBreaching"),
+					AlarmActions:     []string{"arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts"},
+				})
+			}
+			return &cloudwatch.DescribeAlarmsOutput{MetricAlarms: alarms}, nil
+		},
+	}
+
+	cwLogsClient := &mockCloudWatchLogsClient{
+		DescribeMetricFiltersFunc: func(ctx context.Context, params *cloudwatchlogs.DescribeMetricFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error) {
+			var filters []cwltypes.MetricFilter
+			for _, expected := range GetDefaultMetricFilters("/aws/cloudtrail/sentinel") {
+				filters = append(filters, cwltypes.MetricFilter{
+					FilterName:    aws.String(expected.Name),
+					FilterPattern: aws.String(expected.FilterPattern),
+				})
+			}
+			return &cloudwatchlogs.DescribeMetricFiltersOutput{MetricFilters: filters}, nil
+		},
+	}
+
+	setup := NewMonitoringSetupWithClients(cwClient, &mockSNSClient{}, cwLogsClient)
+
+	report, err := setup.DetectDrift(ctx, "/aws/cloudtrail/sentinel")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !report.InSync {
+		t.Errorf("expected no drift, got alarm drift %v, filter drift %v", report.AlarmDrift, report.FilterDrift)
+	}
+}
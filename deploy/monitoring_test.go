@@ -3,10 +3,12 @@ package deploy
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatch"
+	cwtypes "github.com/aws/aws-sdk-go-v2/service/cloudwatch/types"
 	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
 	"github.com/aws/aws-sdk-go-v2/service/sns"
 )
@@ -17,8 +19,11 @@ import (
 
 // mockCloudWatchAlarmsClient implements cloudwatchAlarmsAPI for testing.
 type mockCloudWatchAlarmsClient struct {
-	PutMetricAlarmFunc func(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error)
-	DescribeAlarmsFunc func(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error)
+	PutMetricAlarmFunc     func(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error)
+	DescribeAlarmsFunc     func(ctx context.Context, params *cloudwatch.DescribeAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DescribeAlarmsOutput, error)
+	PutCompositeAlarmFunc  func(ctx context.Context, params *cloudwatch.PutCompositeAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutCompositeAlarmOutput, error)
+	PutAnomalyDetectorFunc func(ctx context.Context, params *cloudwatch.PutAnomalyDetectorInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutAnomalyDetectorOutput, error)
+	DeleteAlarmsFunc       func(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error)
 }
 
 func (m *mockCloudWatchAlarmsClient) PutMetricAlarm(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error) {
@@ -35,10 +40,35 @@ func (m *mockCloudWatchAlarmsClient) DescribeAlarms(ctx context.Context, params
 	return &cloudwatch.DescribeAlarmsOutput{}, nil
 }
 
+func (m *mockCloudWatchAlarmsClient) PutCompositeAlarm(ctx context.Context, params *cloudwatch.PutCompositeAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutCompositeAlarmOutput, error) {
+	if m.PutCompositeAlarmFunc != nil {
+		return m.PutCompositeAlarmFunc(ctx, params, optFns...)
+	}
+	return &cloudwatch.PutCompositeAlarmOutput{}, nil
+}
+
+func (m *mockCloudWatchAlarmsClient) PutAnomalyDetector(ctx context.Context, params *cloudwatch.PutAnomalyDetectorInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutAnomalyDetectorOutput, error) {
+	if m.PutAnomalyDetectorFunc != nil {
+		return m.PutAnomalyDetectorFunc(ctx, params, optFns...)
+	}
+	return &cloudwatch.PutAnomalyDetectorOutput{}, nil
+}
+
+func (m *mockCloudWatchAlarmsClient) DeleteAlarms(ctx context.Context, params *cloudwatch.DeleteAlarmsInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.DeleteAlarmsOutput, error) {
+	if m.DeleteAlarmsFunc != nil {
+		return m.DeleteAlarmsFunc(ctx, params, optFns...)
+	}
+	return &cloudwatch.DeleteAlarmsOutput{}, nil
+}
+
 // mockSNSClient implements snsAPI for testing.
 type mockSNSClient struct {
-	CreateTopicFunc func(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error)
-	SubscribeFunc   func(ctx context.Context, params *sns.SubscribeInput, optFns ...func(*sns.Options)) (*sns.SubscribeOutput, error)
+	CreateTopicFunc               func(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error)
+	SubscribeFunc                 func(ctx context.Context, params *sns.SubscribeInput, optFns ...func(*sns.Options)) (*sns.SubscribeOutput, error)
+	SetSubscriptionAttributesFunc func(ctx context.Context, params *sns.SetSubscriptionAttributesInput, optFns ...func(*sns.Options)) (*sns.SetSubscriptionAttributesOutput, error)
+	DeleteTopicFunc               func(ctx context.Context, params *sns.DeleteTopicInput, optFns ...func(*sns.Options)) (*sns.DeleteTopicOutput, error)
+	ListSubscriptionsByTopicFunc  func(ctx context.Context, params *sns.ListSubscriptionsByTopicInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsByTopicOutput, error)
+	UnsubscribeFunc               func(ctx context.Context, params *sns.UnsubscribeInput, optFns ...func(*sns.Options)) (*sns.UnsubscribeOutput, error)
 }
 
 func (m *mockSNSClient) CreateTopic(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error) {
@@ -59,10 +89,39 @@ func (m *mockSNSClient) Subscribe(ctx context.Context, params *sns.SubscribeInpu
 	}, nil
 }
 
+func (m *mockSNSClient) SetSubscriptionAttributes(ctx context.Context, params *sns.SetSubscriptionAttributesInput, optFns ...func(*sns.Options)) (*sns.SetSubscriptionAttributesOutput, error) {
+	if m.SetSubscriptionAttributesFunc != nil {
+		return m.SetSubscriptionAttributesFunc(ctx, params, optFns...)
+	}
+	return &sns.SetSubscriptionAttributesOutput{}, nil
+}
+
+func (m *mockSNSClient) DeleteTopic(ctx context.Context, params *sns.DeleteTopicInput, optFns ...func(*sns.Options)) (*sns.DeleteTopicOutput, error) {
+	if m.DeleteTopicFunc != nil {
+		return m.DeleteTopicFunc(ctx, params, optFns...)
+	}
+	return &sns.DeleteTopicOutput{}, nil
+}
+
+func (m *mockSNSClient) ListSubscriptionsByTopic(ctx context.Context, params *sns.ListSubscriptionsByTopicInput, optFns ...func(*sns.Options)) (*sns.ListSubscriptionsByTopicOutput, error) {
+	if m.ListSubscriptionsByTopicFunc != nil {
+		return m.ListSubscriptionsByTopicFunc(ctx, params, optFns...)
+	}
+	return &sns.ListSubscriptionsByTopicOutput{}, nil
+}
+
+func (m *mockSNSClient) Unsubscribe(ctx context.Context, params *sns.UnsubscribeInput, optFns ...func(*sns.Options)) (*sns.UnsubscribeOutput, error) {
+	if m.UnsubscribeFunc != nil {
+		return m.UnsubscribeFunc(ctx, params, optFns...)
+	}
+	return &sns.UnsubscribeOutput{}, nil
+}
+
 // mockCloudWatchLogsClient implements cloudwatchLogsMonitorAPI for testing.
 type mockCloudWatchLogsClient struct {
 	PutMetricFilterFunc       func(ctx context.Context, params *cloudwatchlogs.PutMetricFilterInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutMetricFilterOutput, error)
 	DescribeMetricFiltersFunc func(ctx context.Context, params *cloudwatchlogs.DescribeMetricFiltersInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DescribeMetricFiltersOutput, error)
+	DeleteMetricFilterFunc    func(ctx context.Context, params *cloudwatchlogs.DeleteMetricFilterInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteMetricFilterOutput, error)
 }
 
 func (m *mockCloudWatchLogsClient) PutMetricFilter(ctx context.Context, params *cloudwatchlogs.PutMetricFilterInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutMetricFilterOutput, error) {
@@ -79,6 +138,13 @@ func (m *mockCloudWatchLogsClient) DescribeMetricFilters(ctx context.Context, pa
 	return &cloudwatchlogs.DescribeMetricFiltersOutput{}, nil
 }
 
+func (m *mockCloudWatchLogsClient) DeleteMetricFilter(ctx context.Context, params *cloudwatchlogs.DeleteMetricFilterInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.DeleteMetricFilterOutput, error) {
+	if m.DeleteMetricFilterFunc != nil {
+		return m.DeleteMetricFilterFunc(ctx, params, optFns...)
+	}
+	return &cloudwatchlogs.DeleteMetricFilterOutput{}, nil
+}
+
 // ============================================================================
 // CreateOrGetSNSTopic Tests
 // ============================================================================
@@ -296,7 +362,7 @@ func TestMonitoringSetup_CreateAlarm_CreatesAlarmWithSNSAction(t *testing.T) {
 		Statistic:         "Sum",
 		Period:            300,
 		EvaluationPeriods: 1,
-		Threshold:         1,
+		Threshold:         aws.Float64(1),
 		ComparisonOp:      "GreaterThanOrEqualToThreshold",
 	}
 
@@ -546,8 +612,8 @@ func TestGetDefaultAlarms_ReturnsCorrectAlarms(t *testing.T) {
 
 	for _, alarm := range alarms {
 		// All alarms should trigger on single occurrence
-		if alarm.Threshold != 1 {
-			t.Errorf("alarm %s: expected threshold 1, got %f", alarm.Name, alarm.Threshold)
+		if alarm.Threshold == nil || *alarm.Threshold != 1 {
+			t.Errorf("alarm %s: expected threshold 1, got %v", alarm.Name, alarm.Threshold)
 		}
 		// All alarms should have 5 minute period
 		if alarm.Period != 300 {
@@ -559,3 +625,230 @@ func TestGetDefaultAlarms_ReturnsCorrectAlarms(t *testing.T) {
 		}
 	}
 }
+
+// ============================================================================
+// SubscribeEndpoints Tests
+// ============================================================================
+
+func TestMonitoringSetup_SubscribeEndpoints_CreatesSlackAndPagerDutySubscriptions(t *testing.T) {
+	ctx := context.Background()
+
+	var subscribedProtocols []string
+	var filterPoliciesSet []string
+
+	snsClient := &mockSNSClient{
+		SubscribeFunc: func(ctx context.Context, params *sns.SubscribeInput, optFns ...func(*sns.Options)) (*sns.SubscribeOutput, error) {
+			subscribedProtocols = append(subscribedProtocols, *params.Protocol)
+			return &sns.SubscribeOutput{
+				SubscriptionArn: aws.String("arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts:" + *params.Protocol),
+			}, nil
+		},
+		SetSubscriptionAttributesFunc: func(ctx context.Context, params *sns.SetSubscriptionAttributesInput, optFns ...func(*sns.Options)) (*sns.SetSubscriptionAttributesOutput, error) {
+			if *params.AttributeName == "FilterPolicy" {
+				filterPoliciesSet = append(filterPoliciesSet, *params.AttributeValue)
+			}
+			return &sns.SetSubscriptionAttributesOutput{}, nil
+		},
+	}
+
+	setup := NewMonitoringSetupWithClients(&mockCloudWatchAlarmsClient{}, snsClient, &mockCloudWatchLogsClient{})
+
+	endpoints := []NotificationEndpoint{
+		NewSlackEndpoint("https://example.com/transform/slack", map[string][]string{"severity": {"low", "medium"}}),
+		NewPagerDutyEndpoint("https://example.com/transform/pagerduty", map[string][]string{"severity": {"critical"}}),
+	}
+
+	results, err := setup.SubscribeEndpoints(ctx, "arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts", endpoints)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(results) != 2 {
+		t.Fatalf("expected 2 subscription results, got %d", len(results))
+	}
+	if len(subscribedProtocols) != 2 || subscribedProtocols[0] != "https" || subscribedProtocols[1] != "https" {
+		t.Errorf("expected 2 https subscriptions, got %v", subscribedProtocols)
+	}
+	if len(filterPoliciesSet) != 2 {
+		t.Errorf("expected filter policies set for both endpoints, got %d", len(filterPoliciesSet))
+	}
+}
+
+func TestMonitoringSetup_SubscribeEndpoints_AccumulatesErrors(t *testing.T) {
+	ctx := context.Background()
+
+	snsClient := &mockSNSClient{
+		SubscribeFunc: func(ctx context.Context, params *sns.SubscribeInput, optFns ...func(*sns.Options)) (*sns.SubscribeOutput, error) {
+			if *params.Protocol == "lambda" {
+				return nil, errors.New("InvalidParameter: lambda function not found")
+			}
+			return &sns.SubscribeOutput{SubscriptionArn: aws.String("arn:aws:sns:...:sqs")}, nil
+		},
+	}
+
+	setup := NewMonitoringSetupWithClients(&mockCloudWatchAlarmsClient{}, snsClient, &mockCloudWatchLogsClient{})
+
+	endpoints := []NotificationEndpoint{
+		NewLambdaEndpoint("arn:aws:lambda:us-east-1:123456789012:function:missing", nil),
+		{Protocol: NotificationProtocolSQS, Endpoint: "arn:aws:sqs:us-east-1:123456789012:sentinel-alerts"},
+	}
+
+	results, err := setup.SubscribeEndpoints(ctx, "arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts", endpoints)
+	if err == nil {
+		t.Fatal("expected error for failed lambda subscription")
+	}
+	if len(results) != 1 {
+		t.Errorf("expected 1 successful subscription result, got %d", len(results))
+	}
+}
+
+func TestMonitoringSetup_SetupSentinelMonitoring_WithNotificationEndpoints(t *testing.T) {
+	ctx := context.Background()
+
+	snsClient := &mockSNSClient{
+		CreateTopicFunc: func(ctx context.Context, params *sns.CreateTopicInput, optFns ...func(*sns.Options)) (*sns.CreateTopicOutput, error) {
+			return &sns.CreateTopicOutput{
+				TopicArn: aws.String("arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts"),
+			}, nil
+		},
+		SubscribeFunc: func(ctx context.Context, params *sns.SubscribeInput, optFns ...func(*sns.Options)) (*sns.SubscribeOutput, error) {
+			return &sns.SubscribeOutput{SubscriptionArn: aws.String("arn:aws:sns:...:slack")}, nil
+		},
+	}
+
+	setup := NewMonitoringSetupWithClients(&mockCloudWatchAlarmsClient{}, snsClient, &mockCloudWatchLogsClient{})
+
+	result, err := setup.SetupSentinelMonitoring(ctx, "aws-cloudtrail-logs", "sentinel-security-alerts", "",
+		NewSlackEndpoint("https://example.com/transform/slack", nil))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Subscriptions) != 1 {
+		t.Errorf("expected 1 subscription recorded, got %d", len(result.Subscriptions))
+	}
+}
+
+func TestGenerateNotificationTransformLambda_ReturnsNonEmptySource(t *testing.T) {
+	src := GenerateNotificationTransformLambda()
+	if !strings.Contains(src, "package main") {
+		t.Error("expected generated Lambda scaffold to declare package main")
+	}
+	if !strings.Contains(src, "TRANSFORM_TARGET") {
+		t.Error("expected generated Lambda scaffold to read TRANSFORM_TARGET")
+	}
+}
+
+// ============================================================================
+// Composite Alarm & Anomaly Detection Tests
+// ============================================================================
+
+func TestMonitoringSetup_CreateCompositeAlarm_CreatesAlarmWithRule(t *testing.T) {
+	ctx := context.Background()
+
+	var capturedInput *cloudwatch.PutCompositeAlarmInput
+	cwClient := &mockCloudWatchAlarmsClient{
+		PutCompositeAlarmFunc: func(ctx context.Context, params *cloudwatch.PutCompositeAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutCompositeAlarmOutput, error) {
+			capturedInput = params
+			return &cloudwatch.PutCompositeAlarmOutput{}, nil
+		},
+	}
+
+	setup := NewMonitoringSetupWithClients(cwClient, &mockSNSClient{}, &mockCloudWatchLogsClient{})
+
+	config := CompositeAlarmConfig{
+		Name:           "sentinel-security-incident",
+		Description:    "Fires on any underlying Sentinel alarm",
+		AlarmRule:      `ALARM("sentinel-kms-key-changes") OR ALARM("sentinel-ssm-delete")`,
+		ActionsEnabled: true,
+		AlarmActions:   []string{"arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts"},
+	}
+
+	if err := setup.CreateCompositeAlarm(ctx, config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if capturedInput == nil || *capturedInput.AlarmRule != config.AlarmRule {
+		t.Errorf("expected alarm rule %q, got %v", config.AlarmRule, capturedInput)
+	}
+	if len(capturedInput.AlarmActions) != 1 {
+		t.Errorf("expected 1 alarm action, got %d", len(capturedInput.AlarmActions))
+	}
+}
+
+func TestGetDefaultCompositeAlarms_CoversAllDefaultAlarms(t *testing.T) {
+	composites := GetDefaultCompositeAlarms()
+	if len(composites) != 1 {
+		t.Fatalf("expected 1 default composite alarm, got %d", len(composites))
+	}
+
+	for _, name := range GetAlarmNames() {
+		if !strings.Contains(composites[0].AlarmRule, name) {
+			t.Errorf("expected composite alarm rule to reference %s, got %s", name, composites[0].AlarmRule)
+		}
+	}
+}
+
+func TestMonitoringSetup_CreateAlarm_AnomalyDetectionCreatesDetectorAndAlarm(t *testing.T) {
+	ctx := context.Background()
+
+	detectorCreated := false
+	var alarmInput *cloudwatch.PutMetricAlarmInput
+
+	cwClient := &mockCloudWatchAlarmsClient{
+		PutAnomalyDetectorFunc: func(ctx context.Context, params *cloudwatch.PutAnomalyDetectorInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutAnomalyDetectorOutput, error) {
+			detectorCreated = true
+			if *params.MetricName != "UnmanagedAssumeRole" {
+				t.Errorf("expected metric UnmanagedAssumeRole, got %s", *params.MetricName)
+			}
+			return &cloudwatch.PutAnomalyDetectorOutput{}, nil
+		},
+		PutMetricAlarmFunc: func(ctx context.Context, params *cloudwatch.PutMetricAlarmInput, optFns ...func(*cloudwatch.Options)) (*cloudwatch.PutMetricAlarmOutput, error) {
+			alarmInput = params
+			return &cloudwatch.PutMetricAlarmOutput{}, nil
+		},
+	}
+
+	setup := NewMonitoringSetupWithClients(cwClient, &mockSNSClient{}, &mockCloudWatchLogsClient{})
+
+	config := AlarmConfig{
+		Name:              "sentinel-unmanaged-assume-role-anomaly",
+		Description:       "Alert on anomalous AssumeRole volume",
+		MetricName:        "UnmanagedAssumeRole",
+		Namespace:         DefaultMetricNamespace,
+		Statistic:         "Sum",
+		Period:            300,
+		EvaluationPeriods: 1,
+		AnomalyDetection:  true,
+	}
+
+	if err := setup.CreateAlarm(ctx, config, "arn:aws:sns:us-east-1:123456789012:sentinel-security-alerts"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !detectorCreated {
+		t.Error("expected PutAnomalyDetector to be called")
+	}
+	if alarmInput == nil || alarmInput.ThresholdMetricId == nil || *alarmInput.ThresholdMetricId != "ad1" {
+		t.Errorf("expected ThresholdMetricId 'ad1', got %v", alarmInput)
+	}
+	if alarmInput.ComparisonOperator != cwtypes.ComparisonOperatorLessThanLowerOrGreaterThanUpperThreshold {
+		t.Errorf("expected anomaly comparison operator, got %s", alarmInput.ComparisonOperator)
+	}
+}
+
+func TestMonitoringSetup_CreateAlarm_MissingThresholdWithoutAnomalyDetectionErrors(t *testing.T) {
+	ctx := context.Background()
+	setup := NewMonitoringSetupWithClients(&mockCloudWatchAlarmsClient{}, &mockSNSClient{}, &mockCloudWatchLogsClient{})
+
+	config := AlarmConfig{
+		Name:       "sentinel-missing-threshold",
+		MetricName: "SomeMetric",
+		Namespace:  DefaultMetricNamespace,
+		Statistic:  "Sum",
+	}
+
+	if err := setup.CreateAlarm(ctx, config, ""); err == nil {
+		t.Error("expected error when Threshold is nil and AnomalyDetection is false")
+	}
+}